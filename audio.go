@@ -1,17 +1,14 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
 	"os"
-	"os/exec"
-	"strings"
-	"sync"
 	"time"
+
+	"social/transcribe"
+	"social/tts"
 )
 
 type AzureConfig struct {
@@ -19,9 +16,52 @@ type AzureConfig struct {
 	SubscriptionKey string
 }
 
+// TTSConfig selects and configures the text-to-speech provider, loaded from
+// env vars in loadConfigs.
+type TTSConfig struct {
+	Provider         string
+	Azure            AzureConfig
+	ElevenLabsAPIKey string
+	OpenAIAPIKey     string
+	PiperBinary      string
+	PiperModel       string
+
+	// Per-content voice/style/rate, e.g. a dramatic voice for the post body
+	// and a plain narrator voice for the title.
+	BodyVoice  string
+	BodyStyle  string
+	BodyRate   string
+	TitleVoice string
+	TitleStyle string
+	TitleRate  string
+}
+
+// TranscribeConfig holds the settings for the in-process transcription
+// subsystem, loaded from env vars in loadConfigs.
+type TranscribeConfig struct {
+	Backend      string
+	ModelPath    string
+	Language     string
+	Threads      int
+	BeamSize     int
+	HTTPEndpoint string
+	HTTPAPIKey   string
+}
+
 type AudioContent struct {
 	text     string
 	fileName string
+	voice    string
+	style    string
+	rate     string
+}
+
+// Word is a single word within a subtitle entry, carrying its own timing so
+// callers can render karaoke-style word-highlighted captions.
+type Word struct {
+	Text      string
+	StartTime time.Duration
+	EndTime   time.Duration
 }
 
 // SubtitleEntry represents a single subtitle with timing
@@ -30,32 +70,33 @@ type SubtitleEntry struct {
 	StartTime time.Duration
 	EndTime   time.Duration
 	Text      string
+	Words     []Word
 }
 
-// Segment represents the transcription output from Whisper
-type Segment struct {
-	Start float64 `json:"start"`
-	End   float64 `json:"end"`
-	Text  string  `json:"text"`
+// newTranscriber builds a transcribe.Transcriber from the loaded config.
+func newTranscriber(cfg TranscribeConfig) (transcribe.Transcriber, error) {
+	return transcribe.New(transcribe.Config{
+		Backend:      cfg.Backend,
+		ModelPath:    cfg.ModelPath,
+		Language:     cfg.Language,
+		Threads:      cfg.Threads,
+		BeamSize:     cfg.BeamSize,
+		HTTPEndpoint: cfg.HTTPEndpoint,
+		HTTPAPIKey:   cfg.HTTPAPIKey,
+	})
 }
 
-// TranscribeAudio uses a Python Whisper script to transcribe audio
-func TranscribeAudio(audioFile string) ([]Segment, error) {
-	cmd := exec.Command("python3", "sub.py", audioFile)
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	err := cmd.Run()
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to run whisper script: %v", err)
-	}
-
-	var segments []Segment
-	if err := json.Unmarshal(out.Bytes(), &segments); err != nil {
-		return nil, fmt.Errorf("failed to parse whisper output: %v", err)
-	}
-
-	return segments, nil
+// newTTSProvider builds a tts.Provider from the loaded config.
+func newTTSProvider(cfg TTSConfig) (tts.Provider, error) {
+	return tts.New(tts.Config{
+		Provider:             cfg.Provider,
+		AzureRegion:          cfg.Azure.Region,
+		AzureSubscriptionKey: cfg.Azure.SubscriptionKey,
+		ElevenLabsAPIKey:     cfg.ElevenLabsAPIKey,
+		OpenAIAPIKey:         cfg.OpenAIAPIKey,
+		PiperBinary:          cfg.PiperBinary,
+		PiperModel:           cfg.PiperModel,
+	})
 }
 
 // formatDuration converts duration to simplified timestamp format (SS,mmm)
@@ -89,8 +130,11 @@ func saveSubtitlesToFile(entries []SubtitleEntry) error {
 	return nil
 }
 
-// ConvertSegmentsToSubtitles converts Whisper segments to subtitle entries
-func ConvertSegmentsToSubtitles(segments []Segment) []SubtitleEntry {
+// ConvertSegmentsToSubtitles converts transcription segments to subtitle
+// entries, carrying per-word timings through when the backend provided them
+// so the renderer can burn in karaoke-style captions instead of whole-segment
+// blocks.
+func ConvertSegmentsToSubtitles(segments []transcribe.Segment) []SubtitleEntry {
 	var entries []SubtitleEntry
 
 	for i, segment := range segments {
@@ -98,12 +142,22 @@ func ConvertSegmentsToSubtitles(segments []Segment) []SubtitleEntry {
 		start := time.Duration(segment.Start * float64(time.Second))
 		end := time.Duration(segment.End * float64(time.Second))
 
+		var words []Word
+		for _, w := range segment.Words {
+			words = append(words, Word{
+				Text:      w.Text,
+				StartTime: time.Duration(w.Start * float64(time.Second)),
+				EndTime:   time.Duration(w.End * float64(time.Second)),
+			})
+		}
+
 		// Create a new SubtitleEntry for each segment
 		entry := SubtitleEntry{
 			Index:     i + 1,
 			StartTime: start,
 			EndTime:   end,
 			Text:      segment.Text,
+			Words:     words,
 		}
 
 		// Append the entry to the list
@@ -113,77 +167,79 @@ func ConvertSegmentsToSubtitles(segments []Segment) []SubtitleEntry {
 	return entries
 }
 
-func textToSpeech(text string, config AzureConfig) ([]byte, error) {
-	url := fmt.Sprintf("https://%s.tts.speech.microsoft.com/cognitiveservices/v1", config.Region)
-
-	ssml := fmt.Sprintf(`<speak version='1.0' xml:lang='en-US'>
-        <voice xml:lang='en-US' xml:gender='Male' name='en-US-AdamMultilingualNeural'>
-            %s
-        </voice>
-    </speak>`, text)
-
-	req, err := http.NewRequest("POST", url, strings.NewReader(ssml))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
-	}
-
-	req.Header.Set("Content-Type", "application/ssml+xml")
-	req.Header.Set("X-Microsoft-OutputFormat", "audio-16khz-128kbitrate-mono-mp3")
-	req.Header.Set("Ocp-Apim-Subscription-Key", config.SubscriptionKey)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+// saveTextToSpeech synthesizes content through the configured TTS provider
+// and saves the result to disk. It returns the provider's SynthesisMeta so
+// callers can skip a Whisper pass when the provider already returned
+// word-level timings.
+func saveTextToSpeech(content AudioContent, provider tts.Provider) (tts.SynthesisMeta, error) {
+	audioData, meta, err := provider.Synthesize(context.Background(), content.text, tts.Options{
+		Voice: content.voice,
+		Style: content.style,
+		Rate:  content.rate,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %v", err)
+		return tts.SynthesisMeta{}, fmt.Errorf("failed to synthesize speech: %v", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	var buffer bytes.Buffer
-	if _, err := io.Copy(&buffer, resp.Body); err != nil {
-		return nil, fmt.Errorf("failed to read response body: %v", err)
+	filePath := fmt.Sprintf("audio/text-to-speech/%s.mp3", content.fileName)
+	if err := os.WriteFile(filePath, audioData, 0644); err != nil {
+		return tts.SynthesisMeta{}, fmt.Errorf("failed to save audio file: %v", err)
 	}
 
-	return buffer.Bytes(), nil
+	log.Printf("Saved audio to %s\n", filePath)
+	return meta, nil
 }
 
-func saveTextToSpeech(content AudioContent, azureConfig AzureConfig) error {
-	audioData, err := textToSpeech(content.text, azureConfig)
-	if err != nil {
-		return fmt.Errorf("failed to synthesize speech: %v", err)
-	}
+// ConvertWordsToSubtitles groups a TTS provider's word-level timings into
+// subtitle entries directly, skipping a second Whisper transcription pass
+// when the provider already returned timing data.
+func ConvertWordsToSubtitles(words []tts.WordTiming) []SubtitleEntry {
+	const wordsPerEntry = 8
 
-	filePath := fmt.Sprintf("audio/text-to-speech/%s.mp3", content.fileName)
-	if err := os.WriteFile(filePath, audioData, 0644); err != nil {
-		return fmt.Errorf("failed to save audio file: %v", err)
+	var entries []SubtitleEntry
+	for i := 0; i < len(words); i += wordsPerEntry {
+		chunk := words[i:min(i+wordsPerEntry, len(words))]
+
+		var text string
+		entryWords := make([]Word, 0, len(chunk))
+		for _, w := range chunk {
+			if text != "" {
+				text += " "
+			}
+			text += w.Text
+			entryWords = append(entryWords, Word{
+				Text:      w.Text,
+				StartTime: time.Duration(w.Start * float64(time.Second)),
+				EndTime:   time.Duration(w.End * float64(time.Second)),
+			})
+		}
+
+		entries = append(entries, SubtitleEntry{
+			Index:     len(entries) + 1,
+			StartTime: entryWords[0].StartTime,
+			EndTime:   entryWords[len(entryWords)-1].EndTime,
+			Text:      text,
+			Words:     entryWords,
+		})
 	}
 
-	log.Printf("Saved audio to %s\n", filePath)
-	return nil
+	return entries
 }
 
-func getSubtitles(wg *sync.WaitGroup) {
-	// Transcribe audio using Whisper
+// transcribeSubtitles runs the configured transcription backend over the
+// post body audio and returns the resulting subtitle entries.
+func transcribeSubtitles(cfg TranscribeConfig) ([]SubtitleEntry, error) {
 	fmt.Println("Creating subtitles....")
-	defer wg.Done()
 
-	segments, err := TranscribeAudio("/home/satyam/social/audio/text-to-speech/post_body.mp3")
+	transcriber, err := newTranscriber(cfg)
 	if err != nil {
-		log.Printf("Error transcribing audio: %v\n", err)
-		return
+		return nil, fmt.Errorf("error setting up transcriber: %v", err)
 	}
 
-	// Convert segments to subtitles
-	subtitles := ConvertSegmentsToSubtitles(segments)
-
-	// Save subtitles to file
-	if err := saveSubtitlesToFile(subtitles); err != nil {
-		log.Printf("Error saving subtitles: %v\n", err)
-	} else {
-		log.Printf("Subtitles downloaded!")
+	segments, err := transcriber.Transcribe(context.Background(), "/home/satyam/social/audio/text-to-speech/post_body.mp3")
+	if err != nil {
+		return nil, fmt.Errorf("error transcribing audio: %v", err)
 	}
+
+	return ConvertSegmentsToSubtitles(segments), nil
 }
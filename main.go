@@ -1,20 +1,29 @@
 package main
 
 import (
-	"fmt"
-	"io"
+	"context"
 	"log"
 	"os"
-	"os/exec"
+	"time"
+
+	"social/render"
+	"social/sourcing"
 )
 
 // TODO: Comments and clean up code
 func main() {
-	redditConfig, azureConfig, err := loadConfigs()
+	redditConfig, ttsConfig, transcribeConfig, sourcingConfig, screenshotConfig, renderConfig, uploadConfig, err := loadConfigs()
 	if err != nil {
 		log.Fatalf("Failed to load configurations: %v", err)
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "upload-worker" {
+		if err := runUploadWorker(uploadConfig); err != nil {
+			log.Fatalf("Upload worker failed: %v", err)
+		}
+		return
+	}
+
 	client, err := initRedditClient(redditConfig)
 	if err != nil {
 		log.Fatalf("Failed to create Reddit client: %v", err)
@@ -22,65 +31,76 @@ func main() {
 
 	log.Println("Reddit client created successfully!")
 
-	post, err := processRedditPosts(client, azureConfig)
+	sourceConfig, err := sourcing.LoadSourceConfig(sourcingConfig.SourceConfigPath)
 	if err != nil {
-		log.Fatalf("Failed to process Reddit posts: %v", err)
+		log.Fatalf("Failed to load source config: %v", err)
 	}
 
-	if err := renderFinalVideo(post.ID); err != nil {
-		log.Fatalf("Failed to render video: %v", err)
+	postStore, err := sourcing.OpenStore(sourcingConfig.PostStorePath)
+	if err != nil {
+		log.Fatalf("Failed to open post store: %v", err)
 	}
+	defer postStore.Close()
 
-	// Save processed id into done list after completing the render
-	if err := saveProcessedID(post.ID); err != nil {
-		log.Fatalf("Failed to save id to hisory: %v", err)
-	}
+	selector := sourcing.NewSelector(client, sourceConfig, postStore)
 
-	//* TODO: Make the uploading script run on the background once a day (Research best times to upload) (pending --> published)
-	if err := uploadVideo(post); err != nil {
-		log.Fatalf("Failed to upload video: %v", err)
+	post, subtitles, err := processRedditPosts(selector, ttsConfig, transcribeConfig, screenshotConfig)
+	if err != nil {
+		log.Fatalf("Failed to process Reddit posts: %v", err)
 	}
-}
-
-func renderFinalVideo(id string) error {
-	// Command to run the Python script
-	cmd := exec.Command("python3", "-u", "editor.py", id)
 
-	// Get the stdout and stderr pipes
-	stdout, err := cmd.StdoutPipe()
-	if err != nil {
-		fmt.Printf("Error getting stdout: %v\n", err)
-		return err
+	if err := renderFinalVideo(post.ID, subtitles, renderConfig); err != nil {
+		log.Fatalf("Failed to render video: %v", err)
 	}
 
-	stderr, err := cmd.StderrPipe()
-	if err != nil {
-		fmt.Printf("Error getting stderr: %v\n", err)
-		return err
+	// Queue each rendered part for upload and return; "contentfactory
+	// upload-worker" drains the queue on its own posting schedule.
+	if err := enqueueUpload(post, uploadConfig); err != nil {
+		log.Fatalf("Failed to enqueue upload: %v", err)
 	}
 
-	// Start the command
-	if err := cmd.Start(); err != nil {
-		fmt.Printf("Error starting command: %v\n", err)
-		return err
+	// Fan the first part out to YouTube Shorts and any enabled TikTok or
+	// Instagram Reels publishers, alongside the queued YouTube upload.
+	if err := publishCrossPosts(post, uploadConfig); err != nil {
+		log.Printf("Cross-post publish failed: %v\n", err)
 	}
+}
 
-	// Function to copy output to stdout in real-time
-	copyOutput := func(reader io.ReadCloser) {
-		defer reader.Close()
-		if _, err := io.Copy(io.Writer(os.Stdout), reader); err != nil {
-			fmt.Printf("Error copying output: %v\n", err)
-		}
+// renderFinalVideo assembles the title/body audio, post card, background
+// clip, and subtitles into the split <postID>_part_N.mp4 files.
+func renderFinalVideo(id string, subtitles []SubtitleEntry, cfg RenderConfig) error {
+	job := render.RenderJob{
+		PostID:             id,
+		TitleAudioPath:     "audio/text-to-speech/post_title.mp3",
+		BodyAudioPath:      "audio/text-to-speech/post_body.mp3",
+		PostCardImagePath:  postCardFile,
+		BackgroundClipPath: cfg.BackgroundClipPath,
+		Subtitles:          toRenderSubtitles(subtitles),
+		OutputDir:          cfg.OutputDir,
+		Encoder:            cfg.Encoder,
+		PartDuration:       time.Duration(cfg.PartDurationSecs) * time.Second,
+		Progress: func(update render.ProgressUpdate) {
+			log.Printf("render progress: frame=%d fps=%.1f speed=%s\n", update.Frame, update.FPS, update.Speed)
+		},
 	}
 
-	// Read stdout and stderr in separate goroutines
-	go copyOutput(stdout)
-	go copyOutput(stderr)
+	return render.Render(context.Background(), job)
+}
 
-	// Wait for the command to finish
-	if err := cmd.Wait(); err != nil {
-		return err
+// toRenderSubtitles converts our SubtitleEntry type to render.Subtitle.
+func toRenderSubtitles(entries []SubtitleEntry) []render.Subtitle {
+	subtitles := make([]render.Subtitle, 0, len(entries))
+	for _, e := range entries {
+		words := make([]render.Word, 0, len(e.Words))
+		for _, w := range e.Words {
+			words = append(words, render.Word{Text: w.Text, Start: w.StartTime, End: w.EndTime})
+		}
+		subtitles = append(subtitles, render.Subtitle{
+			Start: e.StartTime,
+			End:   e.EndTime,
+			Text:  e.Text,
+			Words: words,
+		})
 	}
-
-	return nil
+	return subtitles
 }
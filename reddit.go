@@ -3,17 +3,20 @@ package main
 import (
 	"context"
 	"fmt"
+	"image"
+	"image/png"
 	"log"
 	"os"
-	"os/exec"
+	"strconv"
 	"strings"
 	"sync"
-	"time"
-
-	"math/rand"
 
 	"github.com/joho/godotenv"
 	"github.com/vartanbeno/go-reddit/v2/reddit"
+
+	"social/screenshot"
+	"social/sourcing"
+	"social/tts"
 )
 
 type RedditConfig struct {
@@ -23,10 +26,56 @@ type RedditConfig struct {
 	Password     string
 }
 
+// SourcingConfig points at the files backing the sourcing subsystem: the
+// subreddit/filter config and the durable post store that replaced
+// processedPosts.txt.
+type SourcingConfig struct {
+	SourceConfigPath string
+	PostStorePath    string
+}
+
+// ScreenshotConfig controls the headless Chrome renderer used to capture the
+// Reddit post card.
+type ScreenshotConfig struct {
+	ViewportWidth  int
+	ViewportHeight int
+	Theme          string
+	FontPath       string
+}
+
+// RenderConfig controls the ffmpeg video assembly pipeline.
+type RenderConfig struct {
+	BackgroundClipPath string
+	OutputDir          string
+	Encoder            string
+	PartDurationSecs   int
+}
+
+// UploadConfig controls the durable upload queue, the upload-worker's
+// posting schedule, and the cross-post fan out to other platforms.
+type UploadConfig struct {
+	QueuePath             string
+	PostingWindowStartUTC int
+	PostingWindowEndUTC   int
+	DailyUploadCap        int
+
+	TikTokEnabled     bool
+	TikTokAccessToken string
+
+	InstagramEnabled           bool
+	InstagramAccessToken       string
+	InstagramBusinessAccountID string
+
+	S3Bucket          string
+	S3Region          string
+	S3Endpoint        string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+}
+
 const (
-	processedPostFile = "video/pending/processedPosts.txt"
-	enviroment        = "private/info.env"
-	embedURL          = "https://publish.reddit.com/embed?url="
+	enviroment = "private/info.env"
+	embedURL   = "https://publish.reddit.com/embed?url="
 )
 
 func initRedditClient(config RedditConfig) (*reddit.Client, error) {
@@ -39,9 +88,9 @@ func initRedditClient(config RedditConfig) (*reddit.Client, error) {
 	}, reddit.WithUserAgent(userAgent))
 }
 
-func loadConfigs() (RedditConfig, AzureConfig, error) {
+func loadConfigs() (RedditConfig, TTSConfig, TranscribeConfig, SourcingConfig, ScreenshotConfig, RenderConfig, UploadConfig, error) {
 	if err := godotenv.Load(enviroment); err != nil {
-		return RedditConfig{}, AzureConfig{}, fmt.Errorf("error loading .env file: %v", err)
+		return RedditConfig{}, TTSConfig{}, TranscribeConfig{}, SourcingConfig{}, ScreenshotConfig{}, RenderConfig{}, UploadConfig{}, fmt.Errorf("error loading .env file: %v", err)
 	}
 
 	redditConfig := RedditConfig{
@@ -51,142 +100,223 @@ func loadConfigs() (RedditConfig, AzureConfig, error) {
 		Password:     os.Getenv("REDDIT_PASSWORD"),
 	}
 
-	azureConfig := AzureConfig{
-		Region:          os.Getenv("AZURE_SPEECH_REGION"),
-		SubscriptionKey: os.Getenv("AZURE_SPEECH_KEY"),
+	ttsConfig := TTSConfig{
+		Provider: os.Getenv("TTS_PROVIDER"),
+		Azure: AzureConfig{
+			Region:          os.Getenv("AZURE_SPEECH_REGION"),
+			SubscriptionKey: os.Getenv("AZURE_SPEECH_KEY"),
+		},
+		ElevenLabsAPIKey: os.Getenv("ELEVENLABS_API_KEY"),
+		OpenAIAPIKey:     os.Getenv("OPENAI_API_KEY"),
+		PiperBinary:      os.Getenv("PIPER_BINARY"),
+		PiperModel:       os.Getenv("PIPER_MODEL"),
+		// Empty defaults: each provider substitutes its own sane default
+		// voice when unset. "dramatic"/"narrator" aren't real voice IDs for
+		// any of azure/elevenlabs/openai/piper, so leave it to the operator
+		// to set TTS_VOICE_BODY/TTS_VOICE_TITLE to an ID their provider
+		// actually recognizes.
+		BodyVoice:  os.Getenv("TTS_VOICE_BODY"),
+		BodyStyle:  os.Getenv("TTS_STYLE_BODY"),
+		BodyRate:   os.Getenv("TTS_RATE_BODY"),
+		TitleVoice: os.Getenv("TTS_VOICE_TITLE"),
+		TitleStyle: os.Getenv("TTS_STYLE_TITLE"),
+		TitleRate:  os.Getenv("TTS_RATE_TITLE"),
 	}
 
-	return redditConfig, azureConfig, nil
-}
+	transcribeConfig := TranscribeConfig{
+		Backend:      os.Getenv("WHISPER_BACKEND"),
+		ModelPath:    os.Getenv("WHISPER_MODEL_PATH"),
+		Language:     os.Getenv("WHISPER_LANGUAGE"),
+		Threads:      atoiOrDefault(os.Getenv("WHISPER_THREADS"), 4),
+		BeamSize:     atoiOrDefault(os.Getenv("WHISPER_BEAM_SIZE"), 5),
+		HTTPEndpoint: os.Getenv("WHISPER_HTTP_ENDPOINT"),
+		HTTPAPIKey:   os.Getenv("WHISPER_HTTP_API_KEY"),
+	}
 
-// TODO: Later let the user pick how posts they want + what subreddit they want
-func getRandomRedditPosts(client *reddit.Client) ([]*reddit.Post, error) {
-	opts := &reddit.ListPostOptions{
-		ListOptions: reddit.ListOptions{
-			Limit: 25,
-		},
-		Time: "day",
+	sourcingConfig := SourcingConfig{
+		SourceConfigPath: stringOrDefault(os.Getenv("SOURCE_CONFIG_PATH"), "config/sources.json"),
+		PostStorePath:    stringOrDefault(os.Getenv("POST_STORE_PATH"), "private/posts.db"),
 	}
 
-	posts, _, err := client.Subreddit.TopPosts(context.Background(), "AmItheAsshole", opts)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch posts: %v", err)
+	screenshotConfig := ScreenshotConfig{
+		ViewportWidth:  atoiOrDefault(os.Getenv("SCREENSHOT_VIEWPORT_WIDTH"), 0),
+		ViewportHeight: atoiOrDefault(os.Getenv("SCREENSHOT_VIEWPORT_HEIGHT"), 0),
+		Theme:          os.Getenv("SCREENSHOT_THEME"),
+		FontPath:       os.Getenv("SCREENSHOT_FONT_PATH"),
 	}
 
-	seenPosts := make(map[string]bool)
-	today := time.Now().Format("2006-01-02")
-	content, err := os.ReadFile(processedPostFile)
-	if err != nil && !os.IsNotExist(err) {
-		return nil, fmt.Errorf("failed to read history file: %v", err)
+	renderConfig := RenderConfig{
+		BackgroundClipPath: stringOrDefault(os.Getenv("RENDER_BACKGROUND_CLIP"), "assets/background.mp4"),
+		OutputDir:          stringOrDefault(os.Getenv("RENDER_OUTPUT_DIR"), "video/pending"),
+		Encoder:            os.Getenv("RENDER_ENCODER"),
+		PartDurationSecs:   atoiOrDefault(os.Getenv("RENDER_PART_DURATION_SECS"), 59),
 	}
 
-	if len(content) > 0 {
-		lines := strings.Split(string(content), "\n")
-		if len(lines) > 0 && lines[0] != "" {
-			fileDate := lines[0] // First line should be the date
+	uploadConfig := UploadConfig{
+		QueuePath:             stringOrDefault(os.Getenv("UPLOAD_QUEUE_PATH"), "private/uploads.db"),
+		PostingWindowStartUTC: atoiOrDefault(os.Getenv("UPLOAD_WINDOW_START_UTC"), 14),
+		PostingWindowEndUTC:   atoiOrDefault(os.Getenv("UPLOAD_WINDOW_END_UTC"), 22),
+		DailyUploadCap:        atoiOrDefault(os.Getenv("UPLOAD_DAILY_CAP"), 4),
 
-			// If date doesn't match today, clear the file
-			if fileDate != today {
-				// Clear file by creating new empty file with just today's date
-				if err := os.WriteFile(processedPostFile, []byte(today+"\n"), 0644); err != nil {
-					return nil, fmt.Errorf("failed to reset history file: %v", err)
-				}
-			} else {
-				// Date matches, load seen posts
-				for _, id := range lines[1:] { // Skip first line (date)
-					if id != "" {
-						seenPosts[id] = true
-					}
-				}
-			}
-		}
-	} else {
-		// File is empty or doesn't exist, create new file with today's date
-		if err := os.WriteFile(processedPostFile, []byte(today+"\n"), 0644); err != nil {
-			return nil, fmt.Errorf("failed to create history file: %v", err)
-		}
-	}
+		TikTokEnabled:     boolOrDefault(os.Getenv("TIKTOK_ENABLED"), false),
+		TikTokAccessToken: os.Getenv("TIKTOK_ACCESS_TOKEN"),
 
-	var unseenPosts []*reddit.Post
-	for _, post := range posts {
-		if !seenPosts[post.ID] {
-			unseenPosts = append(unseenPosts, post)
-		}
+		InstagramEnabled:           boolOrDefault(os.Getenv("INSTAGRAM_ENABLED"), false),
+		InstagramAccessToken:       os.Getenv("INSTAGRAM_ACCESS_TOKEN"),
+		InstagramBusinessAccountID: os.Getenv("INSTAGRAM_BUSINESS_ACCOUNT_ID"),
+
+		S3Bucket:          os.Getenv("S3_BUCKET"),
+		S3Region:          os.Getenv("S3_REGION"),
+		S3Endpoint:        os.Getenv("S3_ENDPOINT"),
+		S3AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+		S3SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
 	}
 
-	if len(unseenPosts) == 0 {
-		return nil, fmt.Errorf("no unseen posts available")
+	return redditConfig, ttsConfig, transcribeConfig, sourcingConfig, screenshotConfig, renderConfig, uploadConfig, nil
+}
+
+// atoiOrDefault parses s as an int, falling back to def when s is empty or
+// not a valid number.
+func atoiOrDefault(s string, def int) int {
+	if s == "" {
+		return def
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return def
 	}
+	return n
+}
 
-	return unseenPosts, nil
+// stringOrDefault returns s unless it's empty, in which case it returns def.
+func stringOrDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
 }
 
-func processRedditPosts(client *reddit.Client, azureConfig AzureConfig) (*reddit.Post, error) {
-	posts, err := getRandomRedditPosts(client)
+// boolOrDefault parses s as a bool, falling back to def when s is empty or
+// not a valid boolean string.
+func boolOrDefault(s string, def bool) bool {
+	if s == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(s)
 	if err != nil {
-		return nil, err
+		return def
 	}
+	return b
+}
 
-	// TODO: Can allow for processing mulitple posts at once (for now do one at a time)
-	post := posts[rand.Intn(len(posts))]
-	// TODO: Save the pulled posts that wont be used for later to save API calls
+// TODO: Can allow for processing mulitple posts at once (for now do one at a time)
+func processRedditPosts(selector *sourcing.Selector, ttsConfig TTSConfig, transcribeConfig TranscribeConfig, screenshotConfig ScreenshotConfig) (*reddit.Post, []SubtitleEntry, error) {
+	post, err := selector.SelectNextPost(context.Background())
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// Replace the AITA to the full form for when you are converting to text-to-speech
 	if strings.HasPrefix(post.Title, "AITA") {
 		post.Title = strings.Replace(post.Title, "AITA", "Am I the asshole", 1)
 	}
 
+	provider, err := newTTSProvider(ttsConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to set up TTS provider: %v", err)
+	}
+
 	contents := []AudioContent{
-		{text: post.Body, fileName: "post_body"},
-		{text: post.Title, fileName: "post_title"},
+		{text: post.Body, fileName: "post_body", voice: ttsConfig.BodyVoice, style: ttsConfig.BodyStyle, rate: ttsConfig.BodyRate},
+		{text: post.Title, fileName: "post_title", voice: ttsConfig.TitleVoice, style: ttsConfig.TitleStyle, rate: ttsConfig.TitleRate},
 	}
 
+	var bodyMeta tts.SynthesisMeta
 	for _, content := range contents {
-		if err := saveTextToSpeech(content, azureConfig); err != nil {
+		meta, err := saveTextToSpeech(content, provider)
+		if err != nil {
 			log.Printf("Error processing post %s: %v\n", post.ID, err)
 			continue
 		}
+		if content.fileName == "post_body" {
+			bodyMeta = meta
+		}
 	}
 
+	var subtitles []SubtitleEntry
+
 	var wg sync.WaitGroup
 	wg.Add(2)
 	// Get reddit embed (wrap in goroutine later)
-	go getPostImage(post.URL, &wg)
-
-	// Transcribe audio using Whisper (wrap in go routine later)
-	go getSubtitles(&wg)
+	go getPostImage(post, screenshotConfig, &wg)
+
+	if len(bodyMeta.Words) > 0 {
+		// The TTS provider already gave us word timings, so subtitles can be
+		// built directly without a second transcription pass.
+		go func() {
+			defer wg.Done()
+			subtitles = ConvertWordsToSubtitles(bodyMeta.Words)
+			if err := saveSubtitlesToFile(subtitles); err != nil {
+				log.Printf("Error saving subtitles: %v\n", err)
+			} else {
+				log.Printf("Subtitles generated directly from TTS response!")
+			}
+		}()
+	} else {
+		go func() {
+			defer wg.Done()
+			entries, err := transcribeSubtitles(transcribeConfig)
+			if err != nil {
+				log.Printf("%v\n", err)
+				return
+			}
+			subtitles = entries
+			if err := saveSubtitlesToFile(subtitles); err != nil {
+				log.Printf("Error saving subtitles: %v\n", err)
+			} else {
+				log.Printf("Subtitles downloaded!")
+			}
+		}()
+	}
 
 	wg.Wait()
 
-	return post, nil
+	return post, subtitles, nil
 }
 
-func getPostImage(url string, wg *sync.WaitGroup) error {
+const postCardFile = "video/pending/post_card.png"
+
+func getPostImage(post *reddit.Post, cfg ScreenshotConfig, wg *sync.WaitGroup) error {
 	fmt.Println("Grabbing reddit post snapshot....")
 	defer wg.Done()
 
-	cmd := exec.Command("python3", "screenshot.py", embedURL+url)
-	err := cmd.Run()
+	renderer := screenshot.NewRenderer(screenshot.Config{
+		ViewportWidth:  cfg.ViewportWidth,
+		ViewportHeight: cfg.ViewportHeight,
+		Theme:          screenshot.Theme(cfg.Theme),
+		FontPath:       cfg.FontPath,
+	})
 
+	img, err := renderer.Render(context.Background(), embedURL+post.URL, post)
 	if err != nil {
-		return fmt.Errorf("failed to run screenshot script: %v", err)
-	} else {
-		fmt.Println("Got reddit post snapshot!")
+		return fmt.Errorf("failed to render post card: %v", err)
+	}
+
+	if err := savePostCard(img, postCardFile); err != nil {
+		return fmt.Errorf("failed to save post card: %v", err)
 	}
 
+	fmt.Println("Got reddit post snapshot!")
 	return nil
 }
 
-func saveProcessedID(id string) error {
-	// Append the new post ID to history file
-	f, err := os.OpenFile(processedPostFile, os.O_APPEND|os.O_WRONLY, 0644)
+// savePostCard encodes img as a PNG and writes it to path.
+func savePostCard(img image.Image, path string) error {
+	file, err := os.Create(path)
 	if err != nil {
-		return fmt.Errorf("failed to open history file: %v", err)
+		return fmt.Errorf("failed to create file: %v", err)
 	}
-	defer f.Close()
+	defer file.Close()
 
-	if _, err := f.WriteString(id + "\n"); err != nil {
-		return fmt.Errorf("failed to write to history file: %v", err)
-	}
-	return nil
+	return png.Encode(file, img)
 }
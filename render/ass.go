@@ -0,0 +1,67 @@
+package render
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// assHeader is a standard Substation Alpha header sized for a 1080x1920
+// vertical short.
+const assHeader = `[Script Info]
+ScriptType: v4.00+
+PlayResX: 1080
+PlayResY: 1920
+
+[V4+ Styles]
+Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding
+Style: Default,Arial,64,&H00FFFFFF,&H000000FF,&H00000000,&H00000000,1,0,1,3,0,2,40,40,120,1
+
+[Events]
+Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text
+`
+
+// writeASS renders subtitles as an ASS file, using karaoke \k tags per word
+// when word-level timing is available so the burned-in captions highlight
+// word by word instead of as whole-segment blocks.
+func writeASS(subtitles []Subtitle, path string) error {
+	var sb strings.Builder
+	sb.WriteString(assHeader)
+
+	for _, sub := range subtitles {
+		sb.WriteString(fmt.Sprintf("Dialogue: 0,%s,%s,Default,,0,0,0,,%s\n",
+			assTimestamp(sub.Start), assTimestamp(sub.End), assText(sub)))
+	}
+
+	return os.WriteFile(path, []byte(sb.String()), 0644)
+}
+
+// assText renders a subtitle's text, using per-word \k karaoke tags (in
+// centiseconds) when word timing is available.
+func assText(sub Subtitle) string {
+	if len(sub.Words) == 0 {
+		return sub.Text
+	}
+
+	var sb strings.Builder
+	for _, w := range sub.Words {
+		centiseconds := (w.End - w.Start).Milliseconds() / 10
+		sb.WriteString(fmt.Sprintf(`{\k%d}%s `, centiseconds, w.Text))
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+// assTimestamp formats a duration as ASS's H:MM:SS.cc timestamp.
+func assTimestamp(d time.Duration) string {
+	h := d / time.Hour
+	d -= h * time.Hour
+	m := d / time.Minute
+	d -= m * time.Minute
+	s := d / time.Second
+	d -= s * time.Second
+	cs := d / (10 * time.Millisecond)
+
+	return fmt.Sprintf("%d:%02d:%02d.%02d", h, m, s, cs)
+}
@@ -0,0 +1,17 @@
+package render
+
+import (
+	"context"
+)
+
+// concatAudio joins the title and body MP3s into a single track.
+func concatAudio(ctx context.Context, titlePath, bodyPath, outPath string) error {
+	b := ffmpeg().
+		input(titlePath).
+		input(bodyPath).
+		arg("-filter_complex", "[0:a][1:a]concat=n=2:v=0:a=1[a]").
+		arg("-map", "[a]").
+		arg(outPath)
+
+	return runFFmpeg(ctx, b, nil)
+}
@@ -0,0 +1,53 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type composeInputs struct {
+	CombinedAudio string
+	ASSPath       string
+	AudioDuration time.Duration
+	TitleDuration time.Duration
+}
+
+// composeVideo loops the background clip to match the audio, overlays the
+// post card for the title's duration, burns in the karaoke subtitles, and
+// muxes it all against the combined audio track.
+func composeVideo(ctx context.Context, job RenderJob, in composeInputs, outPath string) error {
+	audioSeconds := in.AudioDuration.Seconds()
+	titleSeconds := in.TitleDuration.Seconds()
+
+	filter := fmt.Sprintf(
+		"[0:v]scale=1080:1920:force_original_aspect_ratio=increase,crop=1080:1920,trim=duration=%.3f[bg];"+
+			"[1:v]scale=1080:-1[card];"+
+			"[bg][card]overlay=(W-w)/2:(H-h)/2:enable='lt(t,%.3f)'[withcard];"+
+			"[withcard]ass=%s[outv]",
+		audioSeconds, titleSeconds, escapeFilterPath(in.ASSPath),
+	)
+
+	b := ffmpeg().
+		arg("-stream_loop", "-1").input(job.BackgroundClipPath).
+		arg("-loop", "1").input(job.PostCardImagePath).
+		input(in.CombinedAudio).
+		arg("-filter_complex", filter).
+		arg("-map", "[outv]").
+		arg("-map", "2:a").
+		arg("-c:v", job.encoder()).
+		arg("-c:a", "aac").
+		arg("-t", fmt.Sprintf("%.3f", audioSeconds)).
+		arg("-progress", "pipe:1").
+		arg(outPath)
+
+	return runFFmpeg(ctx, b, job.Progress)
+}
+
+// escapeFilterPath escapes a path for use inside an ffmpeg filtergraph,
+// where ':' and '\' need to be backslash-escaped.
+func escapeFilterPath(path string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `:`, `\:`)
+	return replacer.Replace(path)
+}
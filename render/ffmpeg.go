@@ -0,0 +1,99 @@
+package render
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// cmdBuilder is a small fluent wrapper around building an ffmpeg argument
+// list, so the multi-step pipeline below reads top to bottom instead of as
+// one giant slice literal.
+type cmdBuilder struct {
+	args []string
+}
+
+func ffmpeg() *cmdBuilder {
+	return &cmdBuilder{args: []string{"-y", "-hide_banner", "-loglevel", "error"}}
+}
+
+func (b *cmdBuilder) arg(args ...string) *cmdBuilder {
+	b.args = append(b.args, args...)
+	return b
+}
+
+func (b *cmdBuilder) input(path string) *cmdBuilder {
+	return b.arg("-i", path)
+}
+
+// runFFmpeg executes the built ffmpeg command, streaming progress lines from
+// `-progress pipe:1` (if present) to onProgress.
+func runFFmpeg(ctx context.Context, b *cmdBuilder, onProgress func(ProgressUpdate)) error {
+	cmd := exec.CommandContext(ctx, "ffmpeg", b.args...)
+
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	if onProgress == nil {
+		return runCmd(cmd, stderr.String)
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to attach stdout pipe: %v", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start ffmpeg: %v", err)
+	}
+
+	go streamProgress(stdout, onProgress)
+
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("ffmpeg failed: %v: %s", err, stderr.String())
+	}
+
+	return nil
+}
+
+func runCmd(cmd *exec.Cmd, stderr func() string) error {
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("ffmpeg failed: %v: %s", err, stderr())
+	}
+	return nil
+}
+
+// streamProgress parses ffmpeg's `-progress pipe:1` key=value lines, one
+// update per block terminated by "progress=continue"/"progress=end".
+func streamProgress(r interface {
+	Read([]byte) (int, error)
+}, onProgress func(ProgressUpdate)) {
+	scanner := bufio.NewScanner(r)
+	var current ProgressUpdate
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "frame":
+			current.Frame, _ = strconv.Atoi(value)
+		case "fps":
+			current.FPS, _ = strconv.ParseFloat(value, 64)
+		case "out_time_ms":
+			current.OutTimeMs, _ = strconv.ParseInt(value, 10, 64)
+		case "speed":
+			current.Speed = value
+		case "progress":
+			onProgress(current)
+			current = ProgressUpdate{}
+		}
+	}
+}
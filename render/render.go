@@ -0,0 +1,123 @@
+// Package render drives ffmpeg directly to assemble the final video,
+// replacing the old `python3 editor.py` MoviePy script.
+package render
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Word is a single word with its timing, used to burn karaoke-style
+// subtitles into the final video.
+type Word struct {
+	Text  string
+	Start time.Duration
+	End   time.Duration
+}
+
+// Subtitle is one caption block, optionally broken into words for karaoke
+// highlighting.
+type Subtitle struct {
+	Start time.Duration
+	End   time.Duration
+	Text  string
+	Words []Word
+}
+
+// ProgressUpdate is one sample parsed from ffmpeg's `-progress pipe:1`
+// output.
+type ProgressUpdate struct {
+	Frame     int
+	FPS       float64
+	OutTimeMs int64
+	Speed     string
+}
+
+// RenderJob describes everything needed to assemble one post's video.
+type RenderJob struct {
+	PostID string
+
+	TitleAudioPath      string
+	BodyAudioPath       string
+	PostCardImagePath   string
+	BackgroundClipPath  string
+	Subtitles           []Subtitle
+
+	OutputDir string
+
+	// Encoder is the ffmpeg video encoder to use, e.g. "libx264" (default),
+	// "h264_nvenc", or "h264_videotoolbox".
+	Encoder string
+
+	// PartDuration is the length of each split output part. Defaults to 59s.
+	PartDuration time.Duration
+
+	// Progress, if set, is called with each parsed `-progress` update from
+	// the final encode.
+	Progress func(ProgressUpdate)
+}
+
+func (j RenderJob) encoder() string {
+	if j.Encoder == "" {
+		return "libx264"
+	}
+	return j.Encoder
+}
+
+func (j RenderJob) partDuration() time.Duration {
+	if j.PartDuration == 0 {
+		return 59 * time.Second
+	}
+	return j.PartDuration
+}
+
+// Render assembles title + body audio, overlays the post card, burns in
+// word-timed subtitles, loops a background clip to match, and splits the
+// result into <postID>_part_N.mp4 files under job.OutputDir.
+func Render(ctx context.Context, job RenderJob) error {
+	if job.OutputDir == "" {
+		return fmt.Errorf("render job is missing an output directory")
+	}
+	if err := os.MkdirAll(job.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	combinedAudio := filepath.Join(job.OutputDir, job.PostID+"_combined.mp3")
+	if err := concatAudio(ctx, job.TitleAudioPath, job.BodyAudioPath, combinedAudio); err != nil {
+		return fmt.Errorf("failed to concatenate title/body audio: %v", err)
+	}
+
+	audioDuration, err := probeDuration(ctx, combinedAudio)
+	if err != nil {
+		return fmt.Errorf("failed to probe combined audio duration: %v", err)
+	}
+
+	titleDuration, err := probeDuration(ctx, job.TitleAudioPath)
+	if err != nil {
+		return fmt.Errorf("failed to probe title audio duration: %v", err)
+	}
+
+	assPath := filepath.Join(job.OutputDir, job.PostID+"_subtitles.ass")
+	if err := writeASS(job.Subtitles, assPath); err != nil {
+		return fmt.Errorf("failed to write subtitle file: %v", err)
+	}
+
+	fullVideo := filepath.Join(job.OutputDir, job.PostID+"_full.mp4")
+	if err := composeVideo(ctx, job, composeInputs{
+		CombinedAudio: combinedAudio,
+		ASSPath:       assPath,
+		AudioDuration: audioDuration,
+		TitleDuration: titleDuration,
+	}, fullVideo); err != nil {
+		return fmt.Errorf("failed to compose video: %v", err)
+	}
+
+	if err := splitIntoParts(ctx, fullVideo, job); err != nil {
+		return fmt.Errorf("failed to split video into parts: %v", err)
+	}
+
+	return nil
+}
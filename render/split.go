@@ -0,0 +1,28 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+)
+
+// splitIntoParts uses ffmpeg's segment muxer to cut the rendered video into
+// <postID>_part_N.mp4 files, re-encoding so each part starts on a keyframe.
+func splitIntoParts(ctx context.Context, fullVideoPath string, job RenderJob) error {
+	pattern := filepath.Join(job.OutputDir, job.PostID+"_part_%d.mp4")
+	secs := fmt.Sprintf("%.0f", job.partDuration().Seconds())
+
+	b := ffmpeg().
+		input(fullVideoPath).
+		arg("-map", "0").
+		arg("-c:v", job.encoder()).
+		arg("-force_key_frames", "expr:gte(t,n_forced*"+secs+")").
+		arg("-c:a", "aac").
+		arg("-f", "segment").
+		arg("-segment_time", secs).
+		arg("-reset_timestamps", "1").
+		arg("-segment_start_number", "1").
+		arg(pattern)
+
+	return runFFmpeg(ctx, b, nil)
+}
@@ -0,0 +1,12 @@
+package screenshot
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+)
+
+// decodePNG decodes the raw bytes chromedp.Screenshot captures.
+func decodePNG(data []byte) (image.Image, error) {
+	return png.Decode(bytes.NewReader(data))
+}
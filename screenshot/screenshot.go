@@ -0,0 +1,102 @@
+// Package screenshot renders a Reddit post card to an image, replacing the
+// old `python3 screenshot.py` Selenium shim.
+package screenshot
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	"github.com/vartanbeno/go-reddit/v2/reddit"
+)
+
+// Theme controls the embed's color scheme.
+type Theme string
+
+const (
+	ThemeLight Theme = "light"
+	ThemeDark  Theme = "dark"
+)
+
+// Config controls the headless Chrome viewport and which theme to render.
+type Config struct {
+	ViewportWidth  int
+	ViewportHeight int
+	Theme          Theme
+	Timeout        time.Duration
+
+	// FontPath overrides the font used to draw the synthetic fallback card.
+	// Empty uses defaultFontPath.
+	FontPath string
+}
+
+// Renderer captures a Reddit post card as a PNG of just the card node,
+// falling back to a synthetic card drawn from the post's fields if the embed
+// fails to load or Reddit blocks the request. The capture keeps whatever
+// background the embed page draws; no transparent background is set.
+type Renderer struct {
+	cfg Config
+}
+
+// NewRenderer builds a Renderer, filling in sane defaults for zero-valued
+// fields.
+func NewRenderer(cfg Config) *Renderer {
+	if cfg.ViewportWidth == 0 {
+		cfg.ViewportWidth = 600
+	}
+	if cfg.ViewportHeight == 0 {
+		cfg.ViewportHeight = 800
+	}
+	if cfg.Theme == "" {
+		cfg.Theme = ThemeDark
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 20 * time.Second
+	}
+	return &Renderer{cfg: cfg}
+}
+
+// Render captures the post card at embedURL, falling back to a synthetic
+// card rendered from post when the embed can't be captured.
+func (r *Renderer) Render(ctx context.Context, embedURL string, post *reddit.Post) (image.Image, error) {
+	img, err := r.renderEmbed(ctx, embedURL)
+	if err != nil {
+		fmt.Printf("Embed capture failed, falling back to synthetic card: %v\n", err)
+		return r.renderSynthetic(post)
+	}
+	return img, nil
+}
+
+// renderEmbed drives headless Chrome via CDP to screenshot just the post
+// card node from Reddit's publish-embed page.
+func (r *Renderer) renderEmbed(ctx context.Context, embedURL string) (image.Image, error) {
+	ctx, cancel := chromedp.NewContext(ctx)
+	defer cancel()
+
+	ctx, timeoutCancel := context.WithTimeout(ctx, r.cfg.Timeout)
+	defer timeoutCancel()
+
+	const cardSelector = `blockquote.reddit-embed-bq`
+
+	var buf []byte
+	tasks := chromedp.Tasks{
+		chromedp.EmulateViewport(int64(r.cfg.ViewportWidth), int64(r.cfg.ViewportHeight)),
+		chromedp.Navigate(embedURL),
+		chromedp.WaitVisible(cardSelector, chromedp.ByQuery),
+		chromedp.Evaluate(fmt.Sprintf(`document.documentElement.classList.add(%q)`, string(r.cfg.Theme)), nil),
+		chromedp.Screenshot(cardSelector, &buf, chromedp.NodeVisible, chromedp.ByQuery),
+	}
+
+	if err := chromedp.Run(ctx, tasks); err != nil {
+		return nil, fmt.Errorf("failed to capture post embed: %v", err)
+	}
+
+	img, err := decodePNG(buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode captured screenshot: %v", err)
+	}
+
+	return img, nil
+}
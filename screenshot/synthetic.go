@@ -0,0 +1,57 @@
+package screenshot
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/fogleman/gg"
+	"github.com/vartanbeno/go-reddit/v2/reddit"
+)
+
+// renderSynthetic draws a plain card with the post's title, subreddit,
+// author, and score directly from the *reddit.Post struct, for when the
+// Reddit embed fails to load or gets blocked.
+func (r *Renderer) renderSynthetic(post *reddit.Post) (image.Image, error) {
+	if post == nil {
+		return nil, fmt.Errorf("cannot render synthetic card: post is nil")
+	}
+
+	const (
+		padding  = 32
+		fontSize = 28
+	)
+
+	dc := gg.NewContext(r.cfg.ViewportWidth, r.cfg.ViewportHeight/2)
+
+	if r.cfg.Theme == ThemeDark {
+		dc.SetRGB(0.1, 0.1, 0.1)
+	} else {
+		dc.SetRGB(1, 1, 1)
+	}
+	dc.Clear()
+
+	if r.cfg.Theme == ThemeDark {
+		dc.SetRGB(1, 1, 1)
+	} else {
+		dc.SetRGB(0, 0, 0)
+	}
+
+	fontPath := r.cfg.FontPath
+	if fontPath == "" {
+		fontPath = defaultFontPath
+	}
+
+	// Best-effort: gg falls back to its built-in face if no system font is
+	// available at this path.
+	dc.LoadFontFace(fontPath, fontSize)
+	dc.DrawStringWrapped(post.Title, padding, padding, 0, 0, float64(r.cfg.ViewportWidth-2*padding), 1.5, gg.AlignLeft)
+
+	meta := fmt.Sprintf("r/%s - u/%s - %d points", post.SubredditName, post.Author, post.Score)
+	dc.DrawString(meta, padding, float64(r.cfg.ViewportHeight/2-padding))
+
+	return dc.Image(), nil
+}
+
+// defaultFontPath is a commonly-available system font used for the
+// synthetic card when Config.FontPath (SCREENSHOT_FONT_PATH) isn't set.
+var defaultFontPath = "/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf"
@@ -0,0 +1,48 @@
+// Package sourcing replaces the hardwired r/AmItheAsshole top-of-day fetch
+// with a weighted, filtered, multi-subreddit source selection subsystem.
+package sourcing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// SubredditConfig describes one subreddit to pull posts from and the filters
+// that decide which of its posts are usable.
+type SubredditConfig struct {
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"`
+
+	// TimeWindow is passed straight through to Reddit's top-posts listing:
+	// "day", "week", or "month".
+	TimeWindow string `json:"time_window"`
+
+	MinBodyLength  int     `json:"min_body_length"`
+	MaxBodyLength  int     `json:"max_body_length"`
+	MinUpvoteRatio float32 `json:"min_upvote_ratio"`
+	AllowNSFW      bool    `json:"allow_nsfw"`
+}
+
+// SourceConfig is the full set of subreddits to draw posts from.
+type SourceConfig struct {
+	Subreddits []SubredditConfig `json:"subreddits"`
+}
+
+// LoadSourceConfig reads a JSON source config from disk.
+func LoadSourceConfig(path string) (SourceConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SourceConfig{}, fmt.Errorf("failed to read source config %q: %v", path, err)
+	}
+
+	var cfg SourceConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return SourceConfig{}, fmt.Errorf("failed to parse source config %q: %v", path, err)
+	}
+	if len(cfg.Subreddits) == 0 {
+		return SourceConfig{}, fmt.Errorf("source config %q lists no subreddits", path)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,123 @@
+package sourcing
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/vartanbeno/go-reddit/v2/reddit"
+)
+
+// Selector samples across configured subreddits by weight, draining the
+// reserved cache before hitting Reddit again.
+type Selector struct {
+	client *reddit.Client
+	cfg    SourceConfig
+	store  *Store
+}
+
+// NewSelector builds a Selector over the given subreddit config and store.
+func NewSelector(client *reddit.Client, cfg SourceConfig, store *Store) *Selector {
+	return &Selector{client: client, cfg: cfg, store: store}
+}
+
+// SelectNextPost picks the next post to process: a cached, reserved post if
+// one's available for the chosen subreddit, otherwise a fresh fetch that
+// also tops up the reserve pool with the unused extras.
+func (sel *Selector) SelectNextPost(ctx context.Context) (*reddit.Post, error) {
+	sub := sel.pickWeighted()
+
+	if post, err := sel.store.DrainReserved(sub.Name); err != nil {
+		return nil, fmt.Errorf("failed to drain reserved posts for r/%s: %v", sub.Name, err)
+	} else if post != nil {
+		if err := sel.store.MarkUsed(post, sub.Name); err != nil {
+			return nil, fmt.Errorf("failed to mark post %s used: %v", post.ID, err)
+		}
+		return post, nil
+	}
+
+	candidates, err := sel.fetchAndFilter(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no unseen posts available in r/%s after filtering", sub.Name)
+	}
+
+	chosen := candidates[0]
+	extras := candidates[1:]
+
+	if err := sel.store.Reserve(sub.Name, extras); err != nil {
+		return nil, fmt.Errorf("failed to reserve extra posts for r/%s: %v", sub.Name, err)
+	}
+	if err := sel.store.MarkUsed(chosen, sub.Name); err != nil {
+		return nil, fmt.Errorf("failed to mark post %s used: %v", chosen.ID, err)
+	}
+
+	return chosen, nil
+}
+
+// pickWeighted samples a subreddit proportionally to its configured weight.
+func (sel *Selector) pickWeighted() SubredditConfig {
+	var total float64
+	for _, sub := range sel.cfg.Subreddits {
+		total += sub.Weight
+	}
+	if total <= 0 {
+		return sel.cfg.Subreddits[rand.Intn(len(sel.cfg.Subreddits))]
+	}
+
+	roll := rand.Float64() * total
+	for _, sub := range sel.cfg.Subreddits {
+		roll -= sub.Weight
+		if roll <= 0 {
+			return sub
+		}
+	}
+
+	return sel.cfg.Subreddits[len(sel.cfg.Subreddits)-1]
+}
+
+// fetchAndFilter pulls a fresh page of top posts for sub and applies its
+// length/upvote/NSFW filters, skipping anything already seen. There's no
+// required-flair filter: go-reddit v2.0.1's Post doesn't expose the post's
+// link flair at all, so it can't be checked here.
+func (sel *Selector) fetchAndFilter(ctx context.Context, sub SubredditConfig) ([]*reddit.Post, error) {
+	opts := &reddit.ListPostOptions{
+		ListOptions: reddit.ListOptions{Limit: 25},
+		Time:        sub.TimeWindow,
+	}
+
+	posts, _, err := sel.client.Subreddit.TopPosts(ctx, sub.Name, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch posts from r/%s: %v", sub.Name, err)
+	}
+
+	var filtered []*reddit.Post
+	for _, post := range posts {
+		if len(post.Body) < sub.MinBodyLength {
+			continue
+		}
+		if sub.MaxBodyLength > 0 && len(post.Body) > sub.MaxBodyLength {
+			continue
+		}
+		if post.UpvoteRatio < sub.MinUpvoteRatio {
+			continue
+		}
+		if post.NSFW && !sub.AllowNSFW {
+			continue
+		}
+
+		seen, err := sel.store.Seen(post.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check post history: %v", err)
+		}
+		if seen {
+			continue
+		}
+
+		filtered = append(filtered, post)
+	}
+
+	return filtered, nil
+}
@@ -0,0 +1,147 @@
+package sourcing
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/vartanbeno/go-reddit/v2/reddit"
+	bolt "go.etcd.io/bbolt"
+)
+
+var postsBucket = []byte("posts")
+
+// cachedPost is what's actually stored per post ID: the post itself plus
+// bookkeeping so the "reserved for later" pool can be drained without a
+// second Reddit API call.
+type cachedPost struct {
+	Post      *reddit.Post
+	Subreddit string
+	FetchedAt time.Time
+	UsedAt    *time.Time
+	Reserved  bool
+}
+
+// Store is the durable replacement for processedPosts.txt: every fetched
+// post that passed its subreddit's filters, whether it's been used yet, and
+// the unused extras from each API call so they can be reused across runs
+// instead of discarded. Posts rejected by the filters are not recorded.
+type Store struct {
+	db *bolt.DB
+}
+
+// OpenStore opens (creating if necessary) the BoltDB-backed post store.
+func OpenStore(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0644, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open post store %q: %v", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(postsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize post store: %v", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Seen reports whether a post ID has already been recorded (reserved or
+// used), regardless of whether it's been used yet.
+func (s *Store) Seen(id string) (bool, error) {
+	var seen bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		seen = tx.Bucket(postsBucket).Get([]byte(id)) != nil
+		return nil
+	})
+	return seen, err
+}
+
+// Reserve saves posts that weren't picked this run so future runs can drain
+// them before hitting Reddit again.
+func (s *Store) Reserve(subreddit string, posts []*reddit.Post) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(postsBucket)
+		for _, post := range posts {
+			if bucket.Get([]byte(post.ID)) != nil {
+				continue
+			}
+			record := cachedPost{Post: post, Subreddit: subreddit, FetchedAt: time.Now(), Reserved: true}
+			data, err := json.Marshal(record)
+			if err != nil {
+				return fmt.Errorf("failed to encode cached post %s: %v", post.ID, err)
+			}
+			if err := bucket.Put([]byte(post.ID), data); err != nil {
+				return fmt.Errorf("failed to store cached post %s: %v", post.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// MarkUsed records that a post has been consumed, so it's never served
+// again by DrainReserved or selected a second time.
+func (s *Store) MarkUsed(post *reddit.Post, subreddit string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(postsBucket)
+
+		now := time.Now()
+		record := cachedPost{Post: post, Subreddit: subreddit, FetchedAt: now, UsedAt: &now, Reserved: false}
+
+		if existing := bucket.Get([]byte(post.ID)); existing != nil {
+			var prev cachedPost
+			if err := json.Unmarshal(existing, &prev); err == nil {
+				record.FetchedAt = prev.FetchedAt
+			}
+		}
+
+		data, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("failed to encode post %s: %v", post.ID, err)
+		}
+		return bucket.Put([]byte(post.ID), data)
+	})
+}
+
+// DrainReserved pops one unused, reserved post for the given subreddit, or
+// nil if the reserve pool for that subreddit is empty.
+func (s *Store) DrainReserved(subreddit string) (*reddit.Post, error) {
+	var drained *reddit.Post
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(postsBucket)
+		cursor := bucket.Cursor()
+
+		for id, data := cursor.First(); id != nil; id, data = cursor.Next() {
+			var record cachedPost
+			if err := json.Unmarshal(data, &record); err != nil {
+				continue
+			}
+			if !record.Reserved || record.Subreddit != subreddit {
+				continue
+			}
+
+			record.Reserved = false
+			updated, err := json.Marshal(record)
+			if err != nil {
+				return fmt.Errorf("failed to encode cached post %s: %v", id, err)
+			}
+			if err := bucket.Put(id, updated); err != nil {
+				return fmt.Errorf("failed to update cached post %s: %v", id, err)
+			}
+
+			drained = record.Post
+			return nil
+		}
+		return nil
+	})
+
+	return drained, err
+}
@@ -0,0 +1,106 @@
+package transcribe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// httpTranscriber falls back to a hosted Whisper endpoint (OpenAI or Azure)
+// when the whisper.cpp backend isn't available, e.g. on a build without cgo.
+type httpTranscriber struct {
+	cfg Config
+}
+
+func newHTTPTranscriber(cfg Config) Transcriber {
+	return &httpTranscriber{cfg: cfg}
+}
+
+type transcriptionResponse struct {
+	Segments []struct {
+		Start float64 `json:"start"`
+		End   float64 `json:"end"`
+		Text  string  `json:"text"`
+	} `json:"segments"`
+	Words []struct {
+		Word  string  `json:"word"`
+		Start float64 `json:"start"`
+		End   float64 `json:"end"`
+	} `json:"words"`
+}
+
+func (t *httpTranscriber) Transcribe(ctx context.Context, audioPath string) ([]Segment, error) {
+	if t.cfg.HTTPEndpoint == "" {
+		return nil, fmt.Errorf("WHISPER_HTTP_ENDPOINT is required for the http backend")
+	}
+
+	file, err := os.Open(audioPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audio file: %v", err)
+	}
+	defer file.Close()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreateFormFile("file", filepath.Base(audioPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build multipart request: %v", err)
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, fmt.Errorf("failed to copy audio into request: %v", err)
+	}
+	writer.WriteField("model", "whisper-1")
+	writer.WriteField("response_format", "verbose_json")
+	writer.WriteField("timestamp_granularities[]", "word")
+	if t.cfg.Language != "" {
+		writer.WriteField("language", t.cfg.Language)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize multipart request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", t.cfg.HTTPEndpoint, &body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if t.cfg.HTTPAPIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+t.cfg.HTTPAPIKey)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("transcription API request failed with status %d: %s", resp.StatusCode, string(errBody))
+	}
+
+	var parsed transcriptionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse transcription response: %v", err)
+	}
+
+	segments := make([]Segment, 0, len(parsed.Segments))
+	for _, s := range parsed.Segments {
+		entry := Segment{Start: s.Start, End: s.End, Text: s.Text}
+		for _, w := range parsed.Words {
+			if w.Start >= s.Start && w.End <= s.End {
+				entry.Words = append(entry.Words, Word{Text: w.Word, Start: w.Start, End: w.End})
+			}
+		}
+		segments = append(segments, entry)
+	}
+
+	return segments, nil
+}
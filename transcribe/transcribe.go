@@ -0,0 +1,59 @@
+// Package transcribe turns an audio file into timed text, replacing the old
+// `python3 sub.py` shim with an in-process implementation.
+package transcribe
+
+import (
+	"context"
+	"fmt"
+)
+
+// Word is a single word with its timing inside the audio.
+type Word struct {
+	Text  string
+	Start float64
+	End   float64
+}
+
+// Segment is a chunk of transcribed speech, optionally broken down into
+// per-word timings when the backend supports it.
+type Segment struct {
+	Start float64
+	End   float64
+	Text  string
+	Words []Word
+}
+
+// Config controls which backend is used and how it's tuned. All fields are
+// populated from env vars in loadConfigs.
+type Config struct {
+	// Backend selects the transcription implementation: "whisper.cpp" (default)
+	// or "http" to fall back to a hosted Whisper endpoint.
+	Backend string
+
+	// whisper.cpp settings.
+	ModelPath string
+	Language  string
+	Threads   int
+	BeamSize  int
+
+	// HTTP fallback settings (OpenAI/Azure Whisper endpoints).
+	HTTPEndpoint string
+	HTTPAPIKey   string
+}
+
+// Transcriber turns an audio file into a list of timed segments.
+type Transcriber interface {
+	Transcribe(ctx context.Context, audioPath string) ([]Segment, error)
+}
+
+// New builds the Transcriber selected by cfg.Backend.
+func New(cfg Config) (Transcriber, error) {
+	switch cfg.Backend {
+	case "", "whisper.cpp":
+		return newWhisperCppTranscriber(cfg)
+	case "http":
+		return newHTTPTranscriber(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown transcription backend: %s", cfg.Backend)
+	}
+}
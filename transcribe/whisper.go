@@ -0,0 +1,133 @@
+//go:build cgo
+
+package transcribe
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os/exec"
+
+	whisper "github.com/ggerganov/whisper.cpp/bindings/go/pkg/whisper"
+)
+
+// whisperCppTranscriber runs transcription locally via cgo bindings to
+// whisper.cpp, so we're not spawning a Python process per video.
+//
+// Building this file requires a compiled libwhisper static library on the
+// linker path: build whisper.cpp's "libwhisper.a" (see its Makefile) and
+// point CGO_CFLAGS at its "include" directory and CGO_LDFLAGS at the
+// directory holding libwhisper.a, e.g.:
+//
+//	CGO_CFLAGS="-I/path/to/whisper.cpp/include -I/path/to/whisper.cpp/ggml/include" \
+//	CGO_LDFLAGS="-L/path/to/whisper.cpp/build/src -lwhisper -lm -lstdc++" \
+//	go build ./...
+//
+// Builds without cgo (CGO_ENABLED=0) skip this file entirely; see
+// whisper_stub.go.
+type whisperCppTranscriber struct {
+	model whisper.Model
+	cfg   Config
+}
+
+func newWhisperCppTranscriber(cfg Config) (Transcriber, error) {
+	if cfg.ModelPath == "" {
+		return nil, fmt.Errorf("WHISPER_MODEL_PATH is required for the whisper.cpp backend")
+	}
+
+	model, err := whisper.New(cfg.ModelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load whisper model %q: %v", cfg.ModelPath, err)
+	}
+
+	return &whisperCppTranscriber{model: model, cfg: cfg}, nil
+}
+
+func (t *whisperCppTranscriber) Transcribe(ctx context.Context, audioPath string) ([]Segment, error) {
+	samples, err := decodeMono16k(ctx, audioPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode audio for whisper: %v", err)
+	}
+
+	wctx, err := t.model.NewContext()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create whisper context: %v", err)
+	}
+
+	if t.cfg.Language != "" {
+		if err := wctx.SetLanguage(t.cfg.Language); err != nil {
+			return nil, fmt.Errorf("failed to set whisper language: %v", err)
+		}
+	}
+	if t.cfg.Threads > 0 {
+		wctx.SetThreads(uint(t.cfg.Threads))
+	}
+	if t.cfg.BeamSize > 0 {
+		wctx.SetBeamSize(t.cfg.BeamSize)
+	}
+	wctx.SetTokenTimestamps(true)
+
+	if err := wctx.Process(samples, nil, nil); err != nil {
+		return nil, fmt.Errorf("whisper processing failed: %v", err)
+	}
+
+	var segments []Segment
+	for {
+		seg, err := wctx.NextSegment()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read whisper segment: %v", err)
+		}
+
+		entry := Segment{
+			Start: seg.Start.Seconds(),
+			End:   seg.End.Seconds(),
+			Text:  seg.Text,
+		}
+		for _, tok := range seg.Tokens {
+			entry.Words = append(entry.Words, Word{
+				Text:  tok.Text,
+				Start: tok.Start.Seconds(),
+				End:   tok.End.Seconds(),
+			})
+		}
+		segments = append(segments, entry)
+	}
+
+	return segments, nil
+}
+
+// decodeMono16k shells out to ffmpeg to decode and resample audioPath to
+// 16kHz mono, whisper's expected sample rate, regardless of the source
+// format or sample rate (TTS providers emit mp3 or wav depending on which
+// one is configured).
+func decodeMono16k(ctx context.Context, path string) ([]float32, error) {
+	cmd := exec.CommandContext(ctx, "ffmpeg",
+		"-y", "-hide_banner", "-loglevel", "error",
+		"-i", path,
+		"-f", "f32le", "-ar", "16000", "-ac", "1",
+		"-",
+	)
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("ffmpeg decode failed: %v: %s", err, stderr.String())
+	}
+
+	raw := out.Bytes()
+	samples := make([]float32, len(raw)/4)
+	for i := range samples {
+		bits := binary.LittleEndian.Uint32(raw[i*4 : i*4+4])
+		samples[i] = math.Float32frombits(bits)
+	}
+
+	return samples, nil
+}
@@ -0,0 +1,11 @@
+//go:build !cgo
+
+package transcribe
+
+import "fmt"
+
+// newWhisperCppTranscriber requires cgo to link against whisper.cpp. Builds
+// without cgo (CGO_ENABLED=0) should set WHISPER_BACKEND=http instead.
+func newWhisperCppTranscriber(cfg Config) (Transcriber, error) {
+	return nil, fmt.Errorf("whisper.cpp backend requires building with cgo enabled (CGO_ENABLED=1)")
+}
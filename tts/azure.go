@@ -0,0 +1,65 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// azureProvider synthesizes speech via Azure Cognitive Services, same
+// endpoint the pipeline always used, just behind the Provider interface now.
+type azureProvider struct {
+	region string
+	key    string
+}
+
+func (p *azureProvider) Synthesize(ctx context.Context, text string, opts Options) ([]byte, SynthesisMeta, error) {
+	url := fmt.Sprintf("https://%s.tts.speech.microsoft.com/cognitiveservices/v1", p.region)
+
+	voice := opts.Voice
+	if voice == "" {
+		voice = "en-US-AdamMultilingualNeural"
+	}
+
+	rate := opts.Rate
+	if rate == "" {
+		rate = "default"
+	}
+
+	ssml := fmt.Sprintf(`<speak version='1.0' xml:lang='en-US'>
+        <voice xml:lang='en-US' xml:gender='Male' name='%s'>
+            <prosody rate='%s'>%s</prosody>
+        </voice>
+    </speak>`, voice, rate, text)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(ssml))
+	if err != nil {
+		return nil, SynthesisMeta{}, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/ssml+xml")
+	req.Header.Set("X-Microsoft-OutputFormat", "audio-16khz-128kbitrate-mono-mp3")
+	req.Header.Set("Ocp-Apim-Subscription-Key", p.key)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, SynthesisMeta{}, fmt.Errorf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, SynthesisMeta{}, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var buffer bytes.Buffer
+	if _, err := io.Copy(&buffer, resp.Body); err != nil {
+		return nil, SynthesisMeta{}, fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	meta := SynthesisMeta{SampleRate: 16000, Format: "mp3"}
+	return buffer.Bytes(), meta, nil
+}
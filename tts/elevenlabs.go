@@ -0,0 +1,125 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// elevenLabsProvider synthesizes speech via the ElevenLabs API, which also
+// returns per-character timing we can collapse into word-level timings.
+type elevenLabsProvider struct {
+	apiKey string
+}
+
+func (p *elevenLabsProvider) Synthesize(ctx context.Context, text string, opts Options) ([]byte, SynthesisMeta, error) {
+	voice := opts.Voice
+	if voice == "" {
+		voice = "21m00Tcm4TlvDq8ikWAM" // ElevenLabs' default "Rachel" voice
+	}
+
+	url := fmt.Sprintf("https://api.elevenlabs.io/v1/text-to-speech/%s/with-timestamps", voice)
+
+	body := map[string]any{
+		"text":     text,
+		"model_id": "eleven_multilingual_v2",
+	}
+	if opts.Rate != "" {
+		speed, err := strconv.ParseFloat(opts.Rate, 64)
+		if err != nil {
+			return nil, SynthesisMeta{}, fmt.Errorf("invalid rate %q: %v", opts.Rate, err)
+		}
+		body["voice_settings"] = map[string]any{"speed": speed}
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, SynthesisMeta{}, fmt.Errorf("failed to build request body: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, SynthesisMeta{}, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("xi-api-key", p.apiKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, SynthesisMeta{}, fmt.Errorf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, SynthesisMeta{}, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var parsed struct {
+		AudioBase64         string    `json:"audio_base64"`
+		Alignment           alignment `json:"alignment"`
+		NormalizedAlignment alignment `json:"normalized_alignment"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, SynthesisMeta{}, fmt.Errorf("failed to parse response: %v", err)
+	}
+
+	audio, err := base64.StdEncoding.DecodeString(parsed.AudioBase64)
+	if err != nil {
+		return nil, SynthesisMeta{}, fmt.Errorf("failed to decode audio payload: %v", err)
+	}
+
+	meta := SynthesisMeta{
+		SampleRate: 44100,
+		Format:     "mp3",
+		Words:      wordsFromCharAlignment(parsed.Alignment),
+	}
+
+	return audio, meta, nil
+}
+
+// alignment mirrors ElevenLabs' character-level timing payload.
+type alignment struct {
+	Characters          []string  `json:"characters"`
+	CharacterStartTimes []float64 `json:"character_start_times_seconds"`
+	CharacterEndTimes   []float64 `json:"character_end_times_seconds"`
+}
+
+// wordsFromCharAlignment collapses per-character timings into per-word
+// timings by splitting on whitespace.
+func wordsFromCharAlignment(a alignment) []WordTiming {
+	var words []WordTiming
+	var current []byte
+	var start float64
+	inWord := false
+
+	flush := func(end float64) {
+		if len(current) > 0 {
+			words = append(words, WordTiming{Text: string(current), Start: start, End: end})
+			current = current[:0]
+		}
+	}
+
+	for i, ch := range a.Characters {
+		if ch == " " || ch == "\n" {
+			flush(a.CharacterEndTimes[i])
+			inWord = false
+			continue
+		}
+		if !inWord {
+			start = a.CharacterStartTimes[i]
+			inWord = true
+		}
+		current = append(current, ch...)
+	}
+	if len(a.CharacterEndTimes) > 0 {
+		flush(a.CharacterEndTimes[len(a.CharacterEndTimes)-1])
+	}
+
+	return words
+}
@@ -0,0 +1,69 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// openAIProvider synthesizes speech via the OpenAI TTS endpoint. OpenAI
+// doesn't return word-level timings, so subtitles still need a transcription
+// pass for audio generated this way.
+type openAIProvider struct {
+	apiKey string
+}
+
+func (p *openAIProvider) Synthesize(ctx context.Context, text string, opts Options) ([]byte, SynthesisMeta, error) {
+	voice := opts.Voice
+	if voice == "" {
+		voice = "onyx"
+	}
+
+	body := map[string]any{
+		"model":           "tts-1",
+		"input":           text,
+		"voice":           voice,
+		"response_format": "mp3",
+	}
+	if opts.Rate != "" {
+		speed, err := strconv.ParseFloat(opts.Rate, 64)
+		if err != nil {
+			return nil, SynthesisMeta{}, fmt.Errorf("invalid rate %q: %v", opts.Rate, err)
+		}
+		body["speed"] = speed
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, SynthesisMeta{}, fmt.Errorf("failed to build request body: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.openai.com/v1/audio/speech", bytes.NewReader(payload))
+	if err != nil {
+		return nil, SynthesisMeta{}, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, SynthesisMeta{}, fmt.Errorf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, SynthesisMeta{}, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var buffer bytes.Buffer
+	if _, err := io.Copy(&buffer, resp.Body); err != nil {
+		return nil, SynthesisMeta{}, fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	return buffer.Bytes(), SynthesisMeta{SampleRate: 24000, Format: "mp3"}, nil
+}
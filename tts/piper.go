@@ -0,0 +1,95 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os/exec"
+	"strconv"
+)
+
+// piperProvider pipes text through a local Piper (or Coqui) binary, so
+// synthesis can run fully offline with no API key.
+type piperProvider struct {
+	binary string
+	model  string
+}
+
+func (p *piperProvider) Synthesize(ctx context.Context, text string, opts Options) ([]byte, SynthesisMeta, error) {
+	binary := p.binary
+	if binary == "" {
+		binary = "piper"
+	}
+	if p.model == "" {
+		return nil, SynthesisMeta{}, fmt.Errorf("PIPER_MODEL is required for the piper provider")
+	}
+
+	args := []string{"--model", p.model, "--output-raw"}
+	if opts.Voice != "" {
+		args = append(args, "--speaker", opts.Voice)
+	}
+	if opts.Rate != "" {
+		// Piper's --length_scale stretches/compresses playback duration, so
+		// it's the inverse of a speed: 2x speed is a 0.5 length_scale.
+		speed, err := strconv.ParseFloat(opts.Rate, 64)
+		if err != nil {
+			return nil, SynthesisMeta{}, fmt.Errorf("invalid rate %q: %v", opts.Rate, err)
+		}
+		if speed <= 0 {
+			return nil, SynthesisMeta{}, fmt.Errorf("invalid rate %q: must be positive", opts.Rate)
+		}
+		args = append(args, "--length_scale", strconv.FormatFloat(1/speed, 'f', -1, 64))
+	}
+
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Stdin = bytes.NewReader([]byte(text))
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, SynthesisMeta{}, fmt.Errorf("piper synthesis failed: %v: %s", err, stderr.String())
+	}
+
+	// --output-raw is headerless 16-bit PCM mono at 22.05kHz for Piper's
+	// default models. Wrap it in a WAV header so it's self-describing, same
+	// as the other providers' output, rather than needing every downstream
+	// consumer (saveTextToSpeech, ffmpeg) to special-case a raw format.
+	const sampleRate = 22050
+	return wrapPCMAsWAV(out.Bytes(), sampleRate), SynthesisMeta{SampleRate: sampleRate, Format: "wav"}, nil
+}
+
+// wrapPCMAsWAV prepends a canonical 44-byte RIFF/WAVE header to headerless
+// 16-bit mono PCM samples so the result is a valid, self-describing WAV file.
+func wrapPCMAsWAV(pcm []byte, sampleRate int) []byte {
+	const (
+		channels      = 1
+		bitsPerSample = 16
+	)
+	byteRate := sampleRate * channels * bitsPerSample / 8
+	blockAlign := channels * bitsPerSample / 8
+
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(36+len(pcm)))
+	buf.WriteString("WAVE")
+
+	buf.WriteString("fmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(16)) // PCM fmt chunk size
+	binary.Write(&buf, binary.LittleEndian, uint16(1))  // PCM format tag
+	binary.Write(&buf, binary.LittleEndian, uint16(channels))
+	binary.Write(&buf, binary.LittleEndian, uint32(sampleRate))
+	binary.Write(&buf, binary.LittleEndian, uint32(byteRate))
+	binary.Write(&buf, binary.LittleEndian, uint16(blockAlign))
+	binary.Write(&buf, binary.LittleEndian, uint16(bitsPerSample))
+
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(len(pcm)))
+	buf.Write(pcm)
+
+	return buf.Bytes()
+}
@@ -0,0 +1,68 @@
+// Package tts provides a pluggable text-to-speech abstraction so the pipeline
+// isn't locked into a single provider or voice.
+package tts
+
+import (
+	"context"
+	"fmt"
+)
+
+// WordTiming is a single word and when it's spoken, for providers that can
+// return alignment data alongside the audio.
+type WordTiming struct {
+	Text  string
+	Start float64
+	End   float64
+}
+
+// SynthesisMeta describes the audio a Provider returned.
+type SynthesisMeta struct {
+	SampleRate int
+	Format     string // e.g. "mp3", "wav"
+	Words      []WordTiming
+}
+
+// Options controls voice selection per synthesis call so callers can, e.g.,
+// use a dramatic voice for a post body and a narrator voice for the title.
+type Options struct {
+	Voice string
+	Style string
+	Rate  string
+}
+
+// Provider synthesizes speech from text.
+type Provider interface {
+	Synthesize(ctx context.Context, text string, opts Options) ([]byte, SynthesisMeta, error)
+}
+
+// Config selects and configures a Provider, loaded from env vars in
+// loadConfigs.
+type Config struct {
+	Provider string // "azure" (default), "elevenlabs", "openai", or "piper"
+
+	AzureRegion          string
+	AzureSubscriptionKey string
+
+	ElevenLabsAPIKey string
+
+	OpenAIAPIKey string
+
+	PiperBinary string
+	PiperModel  string
+}
+
+// New builds the Provider selected by cfg.Provider.
+func New(cfg Config) (Provider, error) {
+	switch cfg.Provider {
+	case "", "azure":
+		return &azureProvider{region: cfg.AzureRegion, key: cfg.AzureSubscriptionKey}, nil
+	case "elevenlabs":
+		return &elevenLabsProvider{apiKey: cfg.ElevenLabsAPIKey}, nil
+	case "openai":
+		return &openAIProvider{apiKey: cfg.OpenAIAPIKey}, nil
+	case "piper":
+		return &piperProvider{binary: cfg.PiperBinary, model: cfg.PiperModel}, nil
+	default:
+		return nil, fmt.Errorf("unknown TTS provider: %s", cfg.Provider)
+	}
+}
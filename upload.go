@@ -10,14 +10,16 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/vartanbeno/go-reddit/v2/reddit"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
-	"google.golang.org/api/option"
 	"google.golang.org/api/youtube/v3"
+
+	"social/upload"
 )
 
 const (
@@ -171,99 +173,233 @@ func extractPartNumber(filename string) (int, error) {
 	return partNumber, nil
 }
 
-func getScheduledTime(partNum int) string {
-	// Calculate the scheduled start time for the next video (in UTC)
+// getScheduledTime returns the scheduled publish time (in UTC) for a given
+// part number, spacing parts uploadingInterval hours apart.
+func getScheduledTime(partNum int) time.Time {
 	now := time.Now().UTC()
-	scheduledTime := now.Add(time.Duration(uploadingInterval*(partNum-1)) * time.Hour)
-	return scheduledTime.Format(time.RFC3339) // Format as RFC3339
+	return now.Add(time.Duration(uploadingInterval*(partNum-1)) * time.Hour)
 }
 
-func uploadVideo(post *reddit.Post) error {
-	if err := godotenv.Load("private/info.env"); err != nil {
+// enqueueUpload records one durable upload job per rendered part and
+// returns immediately; the "upload-worker" subcommand drains the queue.
+func enqueueUpload(post *reddit.Post, cfg UploadConfig) error {
+	queue, err := upload.OpenQueue(cfg.QueuePath)
+	if err != nil {
 		return err
 	}
+	defer queue.Close()
 
-	ctx := context.Background()
-
-	// Read the credentials file
-	b, err := os.ReadFile("private/client_secrets.json")
+	filepaths, err := getVideostoUpload(post.ID)
 	if err != nil {
-		return fmt.Errorf("unable to read client secret file: %v", err)
+		return fmt.Errorf("error getting video: %v", err)
 	}
 
-	// Configure OAuth2
-	config, err := google.ConfigFromJSON(b, youtube.YoutubeUploadScope)
-	if err != nil {
-		return fmt.Errorf("unable to parse client secret file to config: %v", err)
+	for _, path := range filepaths {
+		partNum, err := extractPartNumber(path)
+		if err != nil {
+			return fmt.Errorf("error getting part number for %s: %v", path, err)
+		}
+
+		job := upload.Job{
+			ID:          fmt.Sprintf("%s_part_%d_youtube", post.ID, partNum),
+			FilePath:    path,
+			PostID:      post.ID,
+			Platform:    "youtube",
+			Title:       fmt.Sprintf("Part %d | %s", partNum, post.Title),
+			Description: fmt.Sprintf("Credit: %s\n\n%s\n\nURL: %s", post.Author, post.Body, post.URL),
+			ScheduledAt: getScheduledTime(partNum),
+		}
+
+		if err := queue.Enqueue(job); err != nil {
+			return fmt.Errorf("error enqueuing upload job for %s: %v", path, err)
+		}
+
+		log.Printf("Queued %s for upload (scheduled %s)\n", job.ID, job.ScheduledAt.Format(time.RFC3339))
 	}
 
-	client := getClient(config)
+	return nil
+}
+
+// publishedUploader wraps an upload.Uploader and moves a job's file into
+// video/published once the underlying upload succeeds, mirroring the
+// pending -> published move the old synchronous uploader used to do inline.
+type publishedUploader struct {
+	inner upload.Uploader
+}
 
-	service, err := youtube.NewService(ctx, option.WithHTTPClient(client))
+func (p publishedUploader) Upload(ctx context.Context, job upload.Job) (string, error) {
+	videoID, err := p.inner.Upload(ctx, job)
 	if err != nil {
-		return fmt.Errorf("error creating YouTube client: %v", err)
+		return "", err
 	}
 
-	filepaths, err := getVideostoUpload(post.ID)
+	destPath := filepath.Join("video/published", filepath.Base(job.FilePath))
+	if err := os.Rename(job.FilePath, destPath); err != nil {
+		log.Printf("error moving %s to published: %v\n", job.FilePath, err)
+	} else {
+		log.Printf("moved %s to %s\n", job.FilePath, destPath)
+	}
+
+	return videoID, nil
+}
+
+// crossPostPlatforms builds the Publisher set configured for this run: the
+// existing YouTube uploader (published directly as a public Short rather
+// than through the scheduled queue), plus TikTok/Instagram when enabled.
+func crossPostPlatforms(ctx context.Context, cfg UploadConfig) (map[string]upload.Publisher, error) {
+	publishers := map[string]upload.Publisher{}
+
+	b, err := os.ReadFile("private/client_secrets.json")
 	if err != nil {
-		return fmt.Errorf("error getting video: %v", err)
+		return nil, fmt.Errorf("unable to read client secret file: %v", err)
+	}
+	oauthConfig, err := google.ConfigFromJSON(b, youtube.YoutubeUploadScope)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse client secret file to config: %v", err)
+	}
+	youtubeUploader, err := upload.NewYouTubeUploader(ctx, getClient(oauthConfig))
+	if err != nil {
+		return nil, err
 	}
+	publishers["youtube"] = youtubeUploader
 
-	for _, filepath := range filepaths {
-		partNum, err := extractPartNumber(filepath)
-		if err != nil {
-			fmt.Printf("error getting video: %v", err)
-		}
+	if cfg.TikTokEnabled {
+		publishers["tiktok"] = upload.NewTikTokPublisher(cfg.TikTokAccessToken)
+	}
 
-		file, err := os.Open(filepath)
+	if cfg.InstagramEnabled {
+		stager, err := upload.NewS3Stager(ctx, upload.S3StagerConfig{
+			Bucket:          cfg.S3Bucket,
+			Region:          cfg.S3Region,
+			Endpoint:        cfg.S3Endpoint,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+		})
 		if err != nil {
-			return fmt.Errorf("error opening file: %v", err)
-		}
-		defer file.Close()
-
-		// Create the video upload object
-		videoTitle := fmt.Sprintf("Part %d | %s", partNum, post.Title)
-		description := fmt.Sprintf("Credit: %s\n\n%s\n\nURL: %s", post.Author, post.Body, post.URL)
-		fmt.Printf("Uploading video with title: %s\n", videoTitle) // Debug print to check title
-
-		upload := &youtube.Video{
-			Snippet: &youtube.VideoSnippet{
-				Title:       videoTitle,
-				Description: description,
-				CategoryId:  "22",
-				Tags: []string{
-					"#Shorts", "#AITA", "#r/AmItheAsshole", "#Reddit", "#Stories",
-					"#Funny", "#BestOfReddit", "#LOL", "#Entertainment", "#Relatable",
-					"#TrueStories", "#LifeStories", "#Drama", "#DailyDose",
-				},
-			},
-			Status: &youtube.VideoStatus{
-				PrivacyStatus: "public",
-			},
+			return nil, fmt.Errorf("failed to set up instagram video stager: %v", err)
 		}
+		publishers["instagram"] = upload.NewInstagramPublisher(cfg.InstagramAccessToken, cfg.InstagramBusinessAccountID, stager)
+	}
 
-		if partNum > 1 {
-			upload.Status.PrivacyStatus = "private"
-			upload.Status.PublishAt = getScheduledTime(partNum)
-		}
+	return publishers, nil
+}
 
-		call := service.Videos.Insert([]string{"snippet", "status"}, upload)
-		response, err := call.Media(file).Do()
-		if err != nil {
-			return fmt.Errorf("error making YouTube API call: %v", err)
-		}
+// publishCrossPosts publishes the first rendered part to every configured
+// platform concurrently and records each platform's video ID back into the
+// job store, so a single render fans out to YouTube Shorts, TikTok, and
+// Instagram Reels without waiting on them one at a time.
+func publishCrossPosts(post *reddit.Post, cfg UploadConfig) error {
+	ctx := context.Background()
 
-		fmt.Printf("Video uploaded successfully! Video ID: %s\n", response.Id)
+	if err := godotenv.Load(enviroment); err != nil {
+		return err
+	}
 
-		// Move the file to published
-		destPath := "video/published/" + strings.Split(filepath, "/")[2]
-		err = os.Rename(filepath, destPath)
-		if err != nil {
-			fmt.Println("Error moving file:", err)
-		} else {
-			fmt.Println("File moved successfully to ", destPath)
-		}
+	filepaths, err := getVideostoUpload(post.ID)
+	if err != nil {
+		return fmt.Errorf("error getting video: %v", err)
+	}
+	asset := upload.VideoAsset{FilePath: filepaths[0], ThumbnailPath: postCardFile}
+
+	opts := upload.PublishOptions{
+		Title:       post.Title,
+		Description: fmt.Sprintf("Credit: %s\n\n%s\n\nURL: %s", post.Author, post.Body, post.URL),
+		Tags:        []string{"Shorts", "AITA", "Reddit", "Stories"},
+	}
+
+	publishers, err := crossPostPlatforms(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to set up cross-post publishers: %v", err)
+	}
+
+	queue, err := upload.OpenQueue(cfg.QueuePath)
+	if err != nil {
+		return err
+	}
+	defer queue.Close()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []string
+
+	for platform, publisher := range publishers {
+		wg.Add(1)
+		go func(platform string, publisher upload.Publisher) {
+			defer wg.Done()
+
+			result, err := publisher.Publish(ctx, asset, opts)
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Sprintf("%s: %v", platform, err))
+				mu.Unlock()
+				return
+			}
+
+			job := upload.Job{
+				ID:       fmt.Sprintf("%s_%s", post.ID, platform),
+				FilePath: asset.FilePath,
+				PostID:   post.ID,
+				Platform: platform,
+				Title:    opts.Title,
+				VideoID:  result.VideoID,
+			}
+			if err := queue.Record(job); err != nil {
+				log.Printf("failed to record published job for %s: %v\n", platform, err)
+				return
+			}
+
+			log.Printf("published %s -> video ID %s\n", platform, result.VideoID)
+		}(platform, publisher)
 	}
 
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return fmt.Errorf("cross-post publish failures: %s", strings.Join(errs, "; "))
+	}
 	return nil
 }
+
+// runUploadWorker drains the upload queue continuously, respecting the
+// posting window and daily cap. It's the entry point for the
+// "upload-worker" subcommand; once the worker starts it does not return,
+// since the context it runs under is never canceled.
+func runUploadWorker(cfg UploadConfig) error {
+	if err := godotenv.Load(enviroment); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	b, err := os.ReadFile("private/client_secrets.json")
+	if err != nil {
+		return fmt.Errorf("unable to read client secret file: %v", err)
+	}
+
+	oauthConfig, err := google.ConfigFromJSON(b, youtube.YoutubeUploadScope)
+	if err != nil {
+		return fmt.Errorf("unable to parse client secret file to config: %v", err)
+	}
+
+	client := getClient(oauthConfig)
+
+	youtubeUploader, err := upload.NewYouTubeUploader(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	queue, err := upload.OpenQueue(cfg.QueuePath)
+	if err != nil {
+		return err
+	}
+	defer queue.Close()
+
+	worker := upload.NewWorker(queue, publishedUploader{inner: youtubeUploader}, upload.WorkerOptions{
+		PostingWindowStartUTC: cfg.PostingWindowStartUTC,
+		PostingWindowEndUTC:   cfg.PostingWindowEndUTC,
+		DailyUploadCap:        cfg.DailyUploadCap,
+	})
+
+	log.Println("upload worker started, draining queue...")
+	return worker.Run(ctx)
+}
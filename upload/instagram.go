@@ -0,0 +1,174 @@
+package upload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const instagramGraphBase = "https://graph.facebook.com/v19.0"
+
+// InstagramPublisher publishes a Reel via the Graph API's two-step flow:
+// POST /media to start a container from a hosted video URL, poll it until
+// Instagram finishes ingesting the video, then POST /media_publish.
+type InstagramPublisher struct {
+	accessToken       string
+	businessAccountID string
+	stager            Stager
+	client            *http.Client
+
+	pollInterval time.Duration
+	pollTimeout  time.Duration
+}
+
+// NewInstagramPublisher builds an InstagramPublisher. stager is used to turn
+// a local mp4 into the public HTTPS URL the Graph API requires.
+func NewInstagramPublisher(accessToken, businessAccountID string, stager Stager) *InstagramPublisher {
+	return &InstagramPublisher{
+		accessToken:       accessToken,
+		businessAccountID: businessAccountID,
+		stager:            stager,
+		client:            http.DefaultClient,
+		pollInterval:      3 * time.Second,
+		pollTimeout:       2 * time.Minute,
+	}
+}
+
+type instagramContainerResponse struct {
+	ID    string `json:"id"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+type instagramStatusResponse struct {
+	StatusCode string `json:"status_code"`
+}
+
+// Publish stages asset via p.stager, creates a REELS media container from
+// the resulting URL, waits for it to finish processing, then publishes it.
+func (p *InstagramPublisher) Publish(ctx context.Context, asset VideoAsset, opts PublishOptions) (PublishResult, error) {
+	override := opts.For("instagram")
+
+	videoURL, err := p.stager.Stage(ctx, asset.FilePath)
+	if err != nil {
+		return PublishResult{}, fmt.Errorf("failed to stage video for instagram: %v", err)
+	}
+
+	containerID, err := p.createContainer(ctx, videoURL, captionFor(override))
+	if err != nil {
+		return PublishResult{}, err
+	}
+
+	if err := p.waitUntilReady(ctx, containerID); err != nil {
+		return PublishResult{}, err
+	}
+
+	mediaID, err := p.publishContainer(ctx, containerID)
+	if err != nil {
+		return PublishResult{}, err
+	}
+
+	return PublishResult{Platform: "instagram", VideoID: mediaID}, nil
+}
+
+func (p *InstagramPublisher) createContainer(ctx context.Context, videoURL, caption string) (string, error) {
+	form := url.Values{
+		"media_type":   {"REELS"},
+		"video_url":    {videoURL},
+		"caption":      {caption},
+		"access_token": {p.accessToken},
+	}
+
+	var result instagramContainerResponse
+	if err := p.post(ctx, fmt.Sprintf("%s/%s/media", instagramGraphBase, p.businessAccountID), form, &result); err != nil {
+		return "", err
+	}
+	if result.Error != nil {
+		return "", fmt.Errorf("instagram container creation failed: %s", result.Error.Message)
+	}
+
+	return result.ID, nil
+}
+
+// waitUntilReady polls the container's status until it reaches FINISHED, so
+// the subsequent publish call doesn't race Instagram's video ingestion.
+func (p *InstagramPublisher) waitUntilReady(ctx context.Context, containerID string) error {
+	deadline := time.Now().Add(p.pollTimeout)
+
+	for {
+		req, err := http.NewRequestWithContext(ctx, "GET",
+			fmt.Sprintf("%s/%s?fields=status_code&access_token=%s", instagramGraphBase, containerID, url.QueryEscape(p.accessToken)), nil)
+		if err != nil {
+			return fmt.Errorf("failed to build status request: %v", err)
+		}
+
+		resp, err := p.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to check container status: %v", err)
+		}
+
+		var status instagramStatusResponse
+		err = json.NewDecoder(resp.Body).Decode(&status)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to decode status response: %v", err)
+		}
+
+		switch status.StatusCode {
+		case "FINISHED":
+			return nil
+		case "ERROR", "EXPIRED":
+			return fmt.Errorf("instagram container %s failed processing: %s", containerID, status.StatusCode)
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for instagram container %s to finish processing", containerID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(p.pollInterval):
+		}
+	}
+}
+
+func (p *InstagramPublisher) publishContainer(ctx context.Context, containerID string) (string, error) {
+	form := url.Values{
+		"creation_id":  {containerID},
+		"access_token": {p.accessToken},
+	}
+
+	var result instagramContainerResponse
+	if err := p.post(ctx, fmt.Sprintf("%s/%s/media_publish", instagramGraphBase, p.businessAccountID), form, &result); err != nil {
+		return "", err
+	}
+	if result.Error != nil {
+		return "", fmt.Errorf("instagram media_publish failed: %s", result.Error.Message)
+	}
+
+	return result.ID, nil
+}
+
+func (p *InstagramPublisher) post(ctx context.Context, endpoint string, form url.Values, out any) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.URL.RawQuery = form.Encode()
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request to %s: %v", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %v", endpoint, err)
+	}
+	return nil
+}
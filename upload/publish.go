@@ -0,0 +1,65 @@
+package upload
+
+import "context"
+
+// VideoAsset points at a rendered video file (and optional thumbnail) ready
+// to publish to a platform. ThumbnailPath is currently only consumed by the
+// YouTube Publisher (via Thumbnails.Set); TikTok and Instagram derive their
+// thumbnail from the video itself and ignore it.
+type VideoAsset struct {
+	FilePath      string
+	ThumbnailPath string
+}
+
+// PlatformOverride lets a specific platform's publish deviate from the
+// shared title/description/tags, e.g. a shorter TikTok caption.
+type PlatformOverride struct {
+	Title       string
+	Description string
+	Tags        []string
+}
+
+// PublishOptions carries the metadata shared across platforms plus any
+// per-platform overrides, keyed by the same platform string used on Job.
+type PublishOptions struct {
+	Title       string
+	Description string
+	Tags        []string
+
+	Overrides map[string]PlatformOverride
+}
+
+// For applies opts' per-platform override (if any) on top of the shared
+// fields, so a Publisher can call opts.For("tiktok") and use the result
+// directly instead of checking Overrides itself.
+func (o PublishOptions) For(platform string) PlatformOverride {
+	result := PlatformOverride{Title: o.Title, Description: o.Description, Tags: o.Tags}
+
+	override, ok := o.Overrides[platform]
+	if !ok {
+		return result
+	}
+	if override.Title != "" {
+		result.Title = override.Title
+	}
+	if override.Description != "" {
+		result.Description = override.Description
+	}
+	if len(override.Tags) > 0 {
+		result.Tags = override.Tags
+	}
+	return result
+}
+
+// PublishResult is what a Publisher hands back once a video is live.
+type PublishResult struct {
+	Platform string
+	VideoID  string
+}
+
+// Publisher pushes one rendered video to a platform. Unlike Uploader, a
+// Publisher runs synchronously to completion rather than through the
+// retrying queue, so callers that need retry/backoff should wrap it.
+type Publisher interface {
+	Publish(ctx context.Context, asset VideoAsset, opts PublishOptions) (PublishResult, error)
+}
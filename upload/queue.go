@@ -0,0 +1,218 @@
+// Package upload provides a durable, retrying upload queue so a render step
+// can enqueue a job and return immediately instead of uploading in-process
+// and losing all state on the first error.
+//
+// The store is BoltDB (go.etcd.io/bbolt) rather than SQLite: it's a
+// dependency-free single-file embedded store with the same durability
+// properties we need here, and it's what the sourcing package's post cache
+// already uses, so the upload queue follows suit instead of adding a second
+// embedded-database dependency for the same job.
+package upload
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// State is where a Job currently sits in the upload lifecycle.
+type State string
+
+const (
+	StatePending   State = "pending"
+	StateUploading State = "uploading"
+	StateFailed    State = "failed"
+	StateDone      State = "done"
+)
+
+// Job is one video to publish to one platform.
+type Job struct {
+	ID          string
+	FilePath    string
+	PostID      string
+	Platform    string
+	Title       string
+	Description string
+	ScheduledAt time.Time
+
+	State     State
+	Attempts  int
+	LastError string
+	VideoID   string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// NextAttemptAt gates retries behind exponential backoff.
+	NextAttemptAt time.Time
+}
+
+var jobsBucket = []byte("jobs")
+
+// Queue is the durable, BoltDB-backed job store.
+type Queue struct {
+	db *bolt.DB
+}
+
+// OpenQueue opens (creating if necessary) the upload job queue at path.
+func OpenQueue(path string) (*Queue, error) {
+	db, err := bolt.Open(path, 0644, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open upload queue %q: %v", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(jobsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize upload queue: %v", err)
+	}
+
+	return &Queue{db: db}, nil
+}
+
+func (q *Queue) Close() error {
+	return q.db.Close()
+}
+
+// Enqueue adds a new job in the pending state.
+func (q *Queue) Enqueue(job Job) error {
+	now := time.Now()
+	job.State = StatePending
+	job.CreatedAt = now
+	job.UpdatedAt = now
+	job.NextAttemptAt = now
+
+	return q.put(job)
+}
+
+func (q *Queue) put(job Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode job %s: %v", job.ID, err)
+	}
+
+	return q.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(jobsBucket).Put([]byte(job.ID), data)
+	})
+}
+
+// Get fetches a single job by ID.
+func (q *Queue) Get(id string) (*Job, error) {
+	var job *Job
+	err := q.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(jobsBucket).Get([]byte(id))
+		if data == nil {
+			return nil
+		}
+		var j Job
+		if err := json.Unmarshal(data, &j); err != nil {
+			return fmt.Errorf("failed to decode job %s: %v", id, err)
+		}
+		job = &j
+		return nil
+	})
+	return job, err
+}
+
+// All returns every job in the queue, regardless of state.
+func (q *Queue) All() ([]Job, error) {
+	var jobs []Job
+	err := q.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(jobsBucket).ForEach(func(_, data []byte) error {
+			var j Job
+			if err := json.Unmarshal(data, &j); err != nil {
+				return fmt.Errorf("failed to decode job: %v", err)
+			}
+			jobs = append(jobs, j)
+			return nil
+		})
+	})
+	return jobs, err
+}
+
+// NextRunnable returns the oldest job that's pending, ready for retry, and
+// past its scheduled publish time, or nil if none qualify right now.
+func (q *Queue) NextRunnable(now time.Time) (*Job, error) {
+	jobs, err := q.All()
+	if err != nil {
+		return nil, err
+	}
+
+	var next *Job
+	for i := range jobs {
+		j := &jobs[i]
+		if j.State != StatePending && j.State != StateFailed {
+			continue
+		}
+		if j.NextAttemptAt.After(now) || j.ScheduledAt.After(now) {
+			continue
+		}
+		if next == nil || j.CreatedAt.Before(next.CreatedAt) {
+			next = j
+		}
+	}
+
+	return next, nil
+}
+
+// MarkUploading transitions a job to the in-flight state and returns the
+// updated job (with Attempts incremented) so callers can carry the new
+// attempt count into a subsequent MarkDone/MarkFailed call.
+func (q *Queue) MarkUploading(job Job) (Job, error) {
+	job.State = StateUploading
+	job.Attempts++
+	job.UpdatedAt = time.Now()
+	return job, q.put(job)
+}
+
+// MarkDone records a successful upload and the platform's video ID.
+func (q *Queue) MarkDone(job Job, videoID string) error {
+	job.State = StateDone
+	job.VideoID = videoID
+	job.LastError = ""
+	job.UpdatedAt = time.Now()
+	return q.put(job)
+}
+
+// MarkFailed records a failed attempt and schedules the next retry using
+// exponential backoff.
+func (q *Queue) MarkFailed(job Job, cause error, backoff time.Duration) error {
+	job.State = StateFailed
+	job.LastError = cause.Error()
+	job.UpdatedAt = time.Now()
+	job.NextAttemptAt = time.Now().Add(backoff)
+	return q.put(job)
+}
+
+// Record persists a job that was already published synchronously (e.g. a
+// direct cross-post fan out), bypassing the pending/uploading states so the
+// job store still ends up with a complete per-platform history.
+func (q *Queue) Record(job Job) error {
+	now := time.Now()
+	job.State = StateDone
+	job.CreatedAt = now
+	job.UpdatedAt = now
+	return q.put(job)
+}
+
+// CountUploadedSince counts jobs that reached StateDone at or after since,
+// used to enforce a per-day upload cap.
+func (q *Queue) CountUploadedSince(since time.Time) (int, error) {
+	jobs, err := q.All()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, j := range jobs {
+		if j.State == StateDone && !j.UpdatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
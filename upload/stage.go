@@ -0,0 +1,104 @@
+package upload
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// Stager uploads a local file somewhere publicly reachable over HTTPS and
+// hands back a signed URL. Instagram's Graph API requires a hosted video URL
+// rather than a direct file upload, so publishers that target it need one of
+// these.
+type Stager interface {
+	Stage(ctx context.Context, filePath string) (string, error)
+}
+
+// S3StagerConfig configures an S3Stager against either AWS S3 or an
+// S3-compatible endpoint like Cloudflare R2.
+type S3StagerConfig struct {
+	Bucket   string
+	Region   string
+	Endpoint string // non-empty for R2 or other S3-compatible providers
+
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// URLValidFor bounds how long the signed URL stays reachable; Instagram
+	// only needs to fetch it once while processing the publish request.
+	URLValidFor time.Duration
+}
+
+// S3Stager stages local files to an S3 (or R2) bucket and returns a
+// presigned GET URL.
+type S3Stager struct {
+	client   *s3.Client
+	bucket   string
+	validFor time.Duration
+}
+
+// NewS3Stager builds an S3Stager from cfg.
+func NewS3Stager(ctx context.Context, cfg S3StagerConfig) (*S3Stager, error) {
+	awsCfg, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion(cfg.Region),
+		config.WithCredentialsProvider(aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: cfg.AccessKeyID, SecretAccessKey: cfg.SecretAccessKey}, nil
+		})),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %v", err)
+	}
+
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+			o.UsePathStyle = true
+		}
+	})
+
+	validFor := cfg.URLValidFor
+	if validFor == 0 {
+		validFor = 15 * time.Minute
+	}
+
+	return &S3Stager{client: client, bucket: cfg.Bucket, validFor: validFor}, nil
+}
+
+// Stage uploads filePath under a key derived from its base name and returns
+// a presigned URL valid for s.validFor.
+func (s *S3Stager) Stage(ctx context.Context, filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %v", filePath, err)
+	}
+	defer file.Close()
+
+	key := filepath.Base(filePath)
+
+	uploader := manager.NewUploader(s.client)
+	if _, err := uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   file,
+	}); err != nil {
+		return "", fmt.Errorf("failed to stage %s to s3://%s/%s: %v", filePath, s.bucket, key, err)
+	}
+
+	presignClient := s3.NewPresignClient(s.client)
+	request, err := presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(s.validFor))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign %s: %v", key, err)
+	}
+
+	return request.URL, nil
+}
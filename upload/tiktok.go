@@ -0,0 +1,156 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+const tiktokMaxCaptionLen = 2200
+
+// TikTokPublisher publishes a video via TikTok's Content Posting API, using
+// the two-step init-then-upload flow: init returns an upload URL sized for
+// the file, then the raw video bytes are PUT there directly.
+type TikTokPublisher struct {
+	accessToken string
+	client      *http.Client
+}
+
+// NewTikTokPublisher builds a TikTokPublisher authorized with accessToken
+// (obtained via TikTok's OAuth flow ahead of time).
+func NewTikTokPublisher(accessToken string) *TikTokPublisher {
+	return &TikTokPublisher{accessToken: accessToken, client: http.DefaultClient}
+}
+
+type tiktokInitRequest struct {
+	PostInfo   tiktokPostInfo   `json:"post_info"`
+	SourceInfo tiktokSourceInfo `json:"source_info"`
+}
+
+type tiktokPostInfo struct {
+	Title        string `json:"title"`
+	PrivacyLevel string `json:"privacy_level"`
+}
+
+type tiktokSourceInfo struct {
+	Source    string `json:"source"`
+	VideoSize int64  `json:"video_size"`
+}
+
+type tiktokInitResponse struct {
+	Data struct {
+		PublishID string `json:"publish_id"`
+		UploadURL string `json:"upload_url"`
+	} `json:"data"`
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Publish uploads asset to TikTok as a direct post visible only to the
+// account (SELF_ONLY privacy), truncating the
+// caption to TikTok's 2200-character limit.
+func (p *TikTokPublisher) Publish(ctx context.Context, asset VideoAsset, opts PublishOptions) (PublishResult, error) {
+	override := opts.For("tiktok")
+	caption := truncate(captionFor(override), tiktokMaxCaptionLen)
+
+	file, err := os.Open(asset.FilePath)
+	if err != nil {
+		return PublishResult{}, fmt.Errorf("failed to open %s: %v", asset.FilePath, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return PublishResult{}, fmt.Errorf("failed to stat %s: %v", asset.FilePath, err)
+	}
+
+	init, err := p.initUpload(ctx, caption, info.Size())
+	if err != nil {
+		return PublishResult{}, err
+	}
+
+	if err := p.putVideo(ctx, init.Data.UploadURL, file, info.Size()); err != nil {
+		return PublishResult{}, err
+	}
+
+	return PublishResult{Platform: "tiktok", VideoID: init.Data.PublishID}, nil
+}
+
+func (p *TikTokPublisher) initUpload(ctx context.Context, caption string, size int64) (*tiktokInitResponse, error) {
+	payload, err := json.Marshal(tiktokInitRequest{
+		PostInfo:   tiktokPostInfo{Title: caption, PrivacyLevel: "SELF_ONLY"},
+		SourceInfo: tiktokSourceInfo{Source: "FILE_UPLOAD", VideoSize: size},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build init request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://open.tiktokapis.com/v2/post/publish/video/init/", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create init request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.accessToken)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send init request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var init tiktokInitResponse
+	if err := json.NewDecoder(resp.Body).Decode(&init); err != nil {
+		return nil, fmt.Errorf("failed to decode init response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || init.Data.UploadURL == "" {
+		return nil, fmt.Errorf("tiktok init failed: %s %s", init.Error.Code, init.Error.Message)
+	}
+
+	return &init, nil
+}
+
+func (p *TikTokPublisher) putVideo(ctx context.Context, uploadURL string, file io.Reader, size int64) error {
+	req, err := http.NewRequestWithContext(ctx, "PUT", uploadURL, file)
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %v", err)
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "video/mp4")
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", size-1, size))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload video: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("tiktok video upload failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// captionFor builds a caption from a title/description override, since
+// TikTok posts a single caption field rather than separate title/description.
+func captionFor(o PlatformOverride) string {
+	if o.Description == "" {
+		return o.Title
+	}
+	return o.Title + "\n\n" + o.Description
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
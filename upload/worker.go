@@ -0,0 +1,153 @@
+package upload
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+)
+
+// Uploader publishes one job's video file and returns the platform's video
+// ID. Implementations should treat 5xx/quota errors as retryable by
+// returning a plain error (the worker handles backoff uniformly).
+type Uploader interface {
+	Upload(ctx context.Context, job Job) (videoID string, err error)
+}
+
+// WorkerOptions bounds how aggressively the worker drains the queue.
+type WorkerOptions struct {
+	// PollInterval is how often to check for runnable jobs when idle.
+	PollInterval time.Duration
+
+	// PostingWindowStartUTC and PostingWindowEndUTC restrict publishing to a
+	// daily window, e.g. 14 (2pm) to 22 (10pm) UTC.
+	PostingWindowStartUTC int
+	PostingWindowEndUTC   int
+
+	// DailyUploadCap limits how many jobs may reach StateDone per rolling
+	// 24h window. Zero means unlimited.
+	DailyUploadCap int
+
+	// MaxAttempts is how many upload attempts (the first one included) a job
+	// gets before it's left in StateFailed for good.
+	MaxAttempts int
+}
+
+func (o WorkerOptions) pollInterval() time.Duration {
+	if o.PollInterval == 0 {
+		return 30 * time.Second
+	}
+	return o.PollInterval
+}
+
+func (o WorkerOptions) maxAttempts() int {
+	if o.MaxAttempts == 0 {
+		return 5
+	}
+	return o.MaxAttempts
+}
+
+// Worker drains the queue continuously, respecting the posting window and
+// daily cap, retrying failures with exponential backoff.
+type Worker struct {
+	queue    *Queue
+	uploader Uploader
+	opts     WorkerOptions
+}
+
+// NewWorker builds a Worker over queue using uploader to publish jobs.
+func NewWorker(queue *Queue, uploader Uploader, opts WorkerOptions) *Worker {
+	return &Worker{queue: queue, uploader: uploader, opts: opts}
+}
+
+// Run drains the queue until ctx is canceled.
+func (w *Worker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.opts.pollInterval())
+	defer ticker.Stop()
+
+	for {
+		if err := w.tick(ctx); err != nil {
+			log.Printf("upload worker tick failed: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick processes at most one runnable job, so Run's loop naturally respects
+// PollInterval between jobs.
+func (w *Worker) tick(ctx context.Context) error {
+	if !w.withinPostingWindow(time.Now().UTC()) {
+		return nil
+	}
+
+	if w.opts.DailyUploadCap > 0 {
+		count, err := w.queue.CountUploadedSince(time.Now().Add(-24 * time.Hour))
+		if err != nil {
+			return fmt.Errorf("failed to count recent uploads: %v", err)
+		}
+		if count >= w.opts.DailyUploadCap {
+			return nil
+		}
+	}
+
+	job, err := w.queue.NextRunnable(time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to fetch next job: %v", err)
+	}
+	if job == nil {
+		return nil
+	}
+
+	if job.Attempts >= w.opts.maxAttempts() {
+		return nil
+	}
+
+	uploading, err := w.queue.MarkUploading(*job)
+	if err != nil {
+		return fmt.Errorf("failed to mark job %s uploading: %v", job.ID, err)
+	}
+
+	videoID, err := w.uploader.Upload(ctx, uploading)
+	if err != nil {
+		backoff := backoffFor(uploading.Attempts)
+		log.Printf("upload of job %s failed (attempt %d), retrying in %s: %v\n", uploading.ID, uploading.Attempts, backoff, err)
+		return w.queue.MarkFailed(uploading, err, backoff)
+	}
+
+	log.Printf("uploaded job %s -> video ID %s\n", uploading.ID, videoID)
+	return w.queue.MarkDone(uploading, videoID)
+}
+
+// withinPostingWindow reports whether now's UTC hour falls in the
+// configured posting window. A zero-value window means no restriction.
+func (w *Worker) withinPostingWindow(now time.Time) bool {
+	start, end := w.opts.PostingWindowStartUTC, w.opts.PostingWindowEndUTC
+	if start == 0 && end == 0 {
+		return true
+	}
+
+	hour := now.Hour()
+	if start <= end {
+		return hour >= start && hour < end
+	}
+	// Window wraps past midnight, e.g. 22 -> 2.
+	return hour >= start || hour < end
+}
+
+// backoffFor returns an exponential backoff duration, capped at 1 hour.
+func backoffFor(attempts int) time.Duration {
+	const base = 30 * time.Second
+	const max = time.Hour
+
+	backoff := base * time.Duration(math.Pow(2, float64(attempts)))
+	if backoff > max {
+		return max
+	}
+	return backoff
+}
@@ -0,0 +1,123 @@
+package upload
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/youtube/v3"
+)
+
+// YouTubeUploader publishes a job's file via the YouTube Data API's
+// resumable upload protocol, so an interrupted upload resumes from where it
+// left off instead of restarting.
+type YouTubeUploader struct {
+	service *youtube.Service
+}
+
+// NewYouTubeUploader builds a YouTubeUploader from an already-authorized
+// HTTP client (see getClient in the main package for the OAuth flow).
+func NewYouTubeUploader(ctx context.Context, client *http.Client) (*YouTubeUploader, error) {
+	service, err := youtube.NewService(ctx, option.WithHTTPClient(client))
+	if err != nil {
+		return nil, fmt.Errorf("error creating YouTube client: %v", err)
+	}
+	return &YouTubeUploader{service: service}, nil
+}
+
+func (u *YouTubeUploader) Upload(ctx context.Context, job Job) (string, error) {
+	file, err := os.Open(job.FilePath)
+	if err != nil {
+		return "", fmt.Errorf("error opening file: %v", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", fmt.Errorf("error statting file: %v", err)
+	}
+
+	video := &youtube.Video{
+		Snippet: &youtube.VideoSnippet{
+			Title:       job.Title,
+			Description: job.Description,
+			CategoryId:  "22",
+		},
+		Status: &youtube.VideoStatus{
+			PrivacyStatus: "private",
+			PublishAt:     job.ScheduledAt.UTC().Format("2006-01-02T15:04:05Z"),
+		},
+	}
+
+	call := u.service.Videos.Insert([]string{"snippet", "status"}, video)
+
+	// ResumableMedia uses the resumable upload protocol: a large file
+	// interrupted partway through resumes from its last confirmed chunk
+	// instead of restarting.
+	response, err := call.ResumableMedia(ctx, file, info.Size(), "video/mp4").Do()
+	if err != nil {
+		return "", fmt.Errorf("error making YouTube API call: %v", err)
+	}
+
+	return response.Id, nil
+}
+
+// Publish uploads asset directly as a public YouTube Short, without going
+// through the retrying queue. It satisfies Publisher for the cross-post fan
+// out, reusing the same resumable upload call as Upload.
+func (u *YouTubeUploader) Publish(ctx context.Context, asset VideoAsset, opts PublishOptions) (PublishResult, error) {
+	override := opts.For("youtube")
+
+	file, err := os.Open(asset.FilePath)
+	if err != nil {
+		return PublishResult{}, fmt.Errorf("error opening file: %v", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return PublishResult{}, fmt.Errorf("error statting file: %v", err)
+	}
+
+	video := &youtube.Video{
+		Snippet: &youtube.VideoSnippet{
+			Title:       override.Title,
+			Description: override.Description,
+			Tags:        override.Tags,
+			CategoryId:  "22",
+		},
+		Status: &youtube.VideoStatus{
+			PrivacyStatus: "public",
+		},
+	}
+
+	call := u.service.Videos.Insert([]string{"snippet", "status"}, video)
+	response, err := call.ResumableMedia(ctx, file, info.Size(), "video/mp4").Do()
+	if err != nil {
+		return PublishResult{}, fmt.Errorf("error making YouTube API call: %v", err)
+	}
+
+	if asset.ThumbnailPath != "" {
+		if err := u.setThumbnail(ctx, response.Id, asset.ThumbnailPath); err != nil {
+			return PublishResult{}, err
+		}
+	}
+
+	return PublishResult{Platform: "youtube", VideoID: response.Id}, nil
+}
+
+// setThumbnail uploads the post card image as videoID's custom thumbnail.
+func (u *YouTubeUploader) setThumbnail(ctx context.Context, videoID, thumbnailPath string) error {
+	thumb, err := os.Open(thumbnailPath)
+	if err != nil {
+		return fmt.Errorf("error opening thumbnail: %v", err)
+	}
+	defer thumb.Close()
+
+	if _, err := u.service.Thumbnails.Set(videoID).Media(thumb).Do(); err != nil {
+		return fmt.Errorf("error setting YouTube thumbnail: %v", err)
+	}
+	return nil
+}